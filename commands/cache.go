@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"f1cli/data"
+)
+
+// Cache inspects or clears the on-disk response cache
+func Cache(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 || args[0] == "-help" || args[0] == "--help" {
+		ShowCacheHelp()
+		return
+	}
+
+	cache := dataService.GetCache()
+	if cache == nil {
+		fmt.Println("❌ Response cache is disabled")
+		return
+	}
+
+	switch args[0] {
+	case "clear":
+		removed, err := cache.Clear()
+		if err != nil {
+			fmt.Printf("❌ Error clearing cache: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Removed %d cached responses from %s\n", removed, cache.Dir)
+	case "stats":
+		stats, err := cache.Stats()
+		if err != nil {
+			fmt.Printf("❌ Error reading cache: %v\n", err)
+			return
+		}
+		fmt.Println("F1 CLI Response Cache")
+		fmt.Println("══════════════════════════════════════════════")
+		fmt.Printf("Location: %s\n", stats.Dir)
+		fmt.Printf("Entries:  %d\n", stats.Entries)
+		fmt.Printf("Size:     %s\n", formatBytes(stats.Bytes))
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest:   %s\n", stats.Oldest.Format(time.RFC822))
+			fmt.Printf("Newest:   %s\n", stats.Newest.Format(time.RFC822))
+		}
+	default:
+		fmt.Printf("❌ Unknown cache action: %s\n", args[0])
+		ShowCacheHelp()
+	}
+}
+
+// formatBytes renders a byte count in human-readable units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func ShowCacheHelp() {
+	fmt.Println("Manage the local API response cache")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  f1 cache <clear|stats>")
+	fmt.Println()
+	fmt.Println("Actions:")
+	fmt.Println("  clear    Delete every cached response")
+	fmt.Println("  stats    Show cache location, entry count and size")
+	fmt.Println()
+	fmt.Println("Cache lifetimes:")
+	fmt.Println("  Finished sessions     kept forever")
+	fmt.Println("  Schedule and sessions 1 hour")
+	fmt.Println("  'latest' queries      2 minutes")
+	fmt.Println()
+	fmt.Println("Use the global --no-cache flag to bypass the cache for a single run.")
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for degradation command
+const (
+	DegradationReset   = "\033[0m"
+	DegradationBold    = "\033[1m"
+	DegradationRed     = "\033[31m"
+	DegradationGreen   = "\033[32m"
+	DegradationYellow  = "\033[33m"
+	DegradationBlue    = "\033[34m"
+	DegradationMagenta = "\033[35m"
+	DegradationCyan    = "\033[36m"
+	DegradationWhite   = "\033[37m"
+)
+
+// Degradation reports how quickly each compound and each stint lost pace as
+// the tyres aged
+func Degradation(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowDegradationHelp()
+		return
+	}
+
+	location := ""
+	sessionType := "Race"
+	round := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowDegradationHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		case strings.EqualFold(arg, "sprint"):
+			sessionType = "Sprint"
+		case strings.EqualFold(arg, "race"):
+			sessionType = "Race"
+		case location == "":
+			location = arg
+		}
+	}
+
+	if location == "" && round == 0 {
+		ShowDegradationHelp()
+		return
+	}
+
+	sessions, err := dataService.GetSessionsContext(ctx)
+	if err != nil {
+		fmt.Printf("Error getting sessions: %v\n", err)
+		return
+	}
+
+	var candidates []data.Session
+	for _, session := range sessions {
+		if session.Name == sessionType {
+			candidates = append(candidates, session)
+		}
+	}
+	targetSession := selectSession(candidates, location, round, sessionType, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	state := targetSession.State()
+	if state == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, sessionType,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	laps, err := dataService.GetLapsContext(ctx, *targetSession, 0)
+	if err != nil {
+		fmt.Printf("Error getting laps for %s %s: %v\n", targetSession.Location, sessionType, err)
+		return
+	}
+	stints, err := dataService.GetStintsContext(ctx, *targetSession, 0)
+	if err != nil {
+		fmt.Printf("Error getting tyre stints for %s %s: %v\n", targetSession.Location, sessionType, err)
+		return
+	}
+	if len(laps) == 0 || len(stints) == 0 {
+		fmt.Printf("No laps have been run in %s %s yet\n", targetSession.Location, sessionType)
+		return
+	}
+
+	// Without race control messages, safety car laps can't be told apart;
+	// the slow-lap cut still catches most of them
+	messages, flagsErr := dataService.GetRaceControlContext(ctx, *targetSession)
+	neutralised := data.NeutralisedPeriods(messages, targetSession.End())
+
+	names := make(map[int]string)
+	if entrants, err := dataService.GetEntrantsContext(ctx, *targetSession); err == nil {
+		for _, driver := range entrants {
+			names[driver.Number] = driver.Name
+		}
+	}
+
+	analysis := data.AnalyseDegradation(laps, stints, neutralised)
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s Tyre Degradation%s - %s%s%s\n",
+		DegradationBold+DegradationYellow, roundLabel, targetSession.Location, sessionType, DegradationReset,
+		DegradationCyan, targetSession.DateStart.Format("2006-01-02"), DegradationReset)
+	fmt.Printf("%s%s%s\n", DegradationBold, strings.Repeat("═", 80), DegradationReset)
+	if state == data.StateLive {
+		fmt.Printf("%s🔴 LIVE - laps so far%s\n", DegradationBold+DegradationRed, DegradationReset)
+	}
+
+	if len(analysis.Stints) == 0 {
+		fmt.Printf("No stint has %d or more clean laps to fit\n", data.DegradationMinLaps)
+		return
+	}
+
+	fmt.Printf("%sBy compound%s (all stints on a compound, one line per stint with a shared slope)\n",
+		DegradationBold+DegradationYellow, DegradationReset)
+	fmt.Printf("%s%-13s %-12s %-9s %-6s %-7s %-8s%s\n", DegradationBold+DegradationWhite,
+		"COMPOUND", "DEG", "95% CI", "LAPS", "STINTS", "DRIVERS", DegradationReset)
+	fmt.Printf("%s%s%s\n", DegradationBold, strings.Repeat("─", 80), DegradationReset)
+	for _, compound := range analysis.Compounds {
+		fmt.Printf("%s%-13s%s %s %-6d %-7d %d\n",
+			getCompoundColor(compound.Compound), strings.ToLower(compound.Compound), DegradationReset,
+			formatDegradationFit(compound.Fit), compound.Fit.Laps, compound.Fit.Stints, compound.Drivers)
+	}
+
+	// Stints in compound order, the most durable first
+	stintFits := analysis.Stints
+	sort.SliceStable(stintFits, func(i, j int) bool {
+		a, b := strings.ToUpper(stintFits[i].Stint.Compound), strings.ToUpper(stintFits[j].Stint.Compound)
+		if a != b {
+			return data.CompoundOrder(a) < data.CompoundOrder(b)
+		}
+		return stintFits[i].Fit.Slope < stintFits[j].Fit.Slope
+	})
+
+	fmt.Printf("\n%sBy stint%s\n", DegradationBold+DegradationYellow, DegradationReset)
+	fmt.Printf("%s%-22s %-5s %-9s %-8s %-12s %-9s %-5s%s\n", DegradationBold+DegradationWhite,
+		"DRIVER", "TYRE", "LAPS", "AGE", "DEG", "95% CI", "USED", DegradationReset)
+	fmt.Printf("%s%s%s\n", DegradationBold, strings.Repeat("─", 80), DegradationReset)
+	for _, fit := range stintFits {
+		stint := fit.Stint
+		name := names[stint.DriverNumber]
+		if name == "" {
+			name = fmt.Sprintf("Driver #%d", stint.DriverNumber)
+		}
+		first, last := fit.Laps[0].Number, fit.Laps[len(fit.Laps)-1].Number
+		fmt.Printf("%-22s %s%-5s%s %-9s %-8s %s %d\n",
+			truncateString(name, 22),
+			getCompoundColor(stint.Compound), data.CompoundLetter(stint.Compound), DegradationReset,
+			fmt.Sprintf("L%d-%d", first, last),
+			fmt.Sprintf("%d-%d", stint.TyreAgeAt(first), stint.TyreAgeAt(last)),
+			formatDegradationFit(fit.Fit), len(fit.Laps))
+	}
+
+	excluded := analysis.Exclusions
+	fmt.Printf("\n%sDEG%s is seconds lost per lap of tyre age, after adding back %.2fs a lap for fuel burned off.\n",
+		DegradationBold, DegradationReset, data.FuelEffect)
+	fmt.Printf("A %s?%s marks a slope the fit can't tell apart from zero.\n", DegradationMagenta, DegradationReset)
+	fmt.Printf("%sExcluded:%s %d opening, %d pit in/out, %d neutralised, %d in traffic, %d slow\n",
+		DegradationCyan, DegradationReset, excluded.FirstLap, excluded.PitInOut, excluded.Neutralised,
+		excluded.Traffic, excluded.Slow)
+	if flagsErr != nil {
+		fmt.Printf("%sNote:%s race control messages unavailable (%v); safety car laps aren't excluded\n",
+			DegradationMagenta, DegradationReset, flagsErr)
+	}
+}
+
+// formatDegradationFit shows a fitted slope and its confidence interval in
+// two columns, coloured from green (durable) to red (high wear)
+func formatDegradationFit(fit data.DegradationFit) string {
+	color := DegradationGreen
+	switch {
+	case fit.Slope >= 0.1:
+		color = DegradationRed
+	case fit.Slope >= 0.05:
+		color = DegradationYellow
+	}
+	slopeText := fmt.Sprintf("%+.3f s/lap", fit.Slope)
+
+	intervalText, marker := "-", " "
+	if fit.Interval > 0 {
+		intervalText = fmt.Sprintf("±%.3f", fit.Interval)
+	}
+	if !fit.Significant() {
+		marker = DegradationMagenta + "?" + DegradationReset
+	}
+	// ± takes two bytes but one column, so pad by runes
+	padding := strings.Repeat(" ", max(0, 8-len([]rune(intervalText))))
+	return fmt.Sprintf("%s%-12s%s %s%s%s", color, slopeText, DegradationReset, intervalText, padding, marker)
+}
+
+func ShowDegradationHelp() {
+	fmt.Printf("%sF1 Tyre Degradation%s\n", DegradationBold+DegradationYellow, DegradationReset)
+	fmt.Printf("%s%s%s\n", DegradationBold, strings.Repeat("═", 50), DegradationReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", DegradationBold+DegradationGreen, DegradationReset)
+	fmt.Printf("  %sf1 degradation <location|last|next> [sprint]%s\n", DegradationCyan, DegradationReset)
+	fmt.Printf("  %sf1 degradation --round <n> [sprint]%s\n", DegradationCyan, DegradationReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", DegradationBold+DegradationGreen, DegradationReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", DegradationYellow, DegradationReset)
+	fmt.Printf("  %ssprint%s         The sprint instead of the Grand Prix\n", DegradationYellow, DegradationReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", DegradationBold+DegradationGreen, DegradationReset)
+	fmt.Printf("  %sf1 degradation Bahrain%s    # How hard each compound wore in Bahrain\n", DegradationCyan, DegradationReset)
+	fmt.Printf("  %sf1 degradation last%s       # The most recent race\n", DegradationCyan, DegradationReset)
+	fmt.Println()
+	fmt.Printf("%sMethod:%s Lap time is fitted against tyre age for each stint with %d or more\n",
+		DegradationBold+DegradationMagenta, DegradationReset, data.DegradationMinLaps)
+	fmt.Println("      clean laps. The opening lap, pit in and out laps, laps under a safety car")
+	fmt.Println("      or yellow flag, laps started within 1.5s of another car and laps more than")
+	fmt.Println("      5% off the stint's median are left out. Slopes include a fuel correction.")
+}
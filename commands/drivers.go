@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"strings"
@@ -9,7 +11,7 @@ import (
 )
 
 // DriversWithService shows driver information using the provided data service
-func DriversWithService(args []string, dataService *data.DataService) {
+func DriversWithService(ctx context.Context, args []string, dataService *data.DataService) {
 	fs := flag.NewFlagSet("drivers", flag.ExitOnError)
 
 	detailed := fs.Bool("detailed", false, "Show detailed driver information")
@@ -30,12 +32,22 @@ func DriversWithService(args []string, dataService *data.DataService) {
 	// If specific driver requested
 	if len(remaining) > 0 {
 		driverName := strings.Join(remaining, " ")
-		driver, err := dataService.GetDriverByName(driverName)
+		driver, err := dataService.GetDriverByNameContext(ctx, driverName)
 		if err != nil {
-			fmt.Printf("❌ Driver '%s' not found: %v\n", driverName, err)
+			printDriverMatchError(err, nil)
 			return
 		}
-		showDriverDetail(driver)
+		// The roster adds where and for whom the driver raced; it's optional extra detail
+		var appearances *data.RosterEntry
+		if roster, err := dataService.GetRosterContext(ctx); err == nil {
+			for i := range roster {
+				if roster[i].Number == driver.Number {
+					appearances = &roster[i]
+					break
+				}
+			}
+		}
+		showDriverDetail(driver, appearances)
 		return
 	}
 
@@ -45,13 +57,13 @@ func DriversWithService(args []string, dataService *data.DataService) {
 		teamFilter = *teamShort
 	}
 
-	drivers, err := dataService.GetDrivers()
+	drivers, err := dataService.GetDriversContext(ctx)
 	if err != nil {
 		fmt.Printf("❌ Error fetching drivers: %v\n", err)
 		return
 	}
 
-	fmt.Printf("F1 2025 Drivers (%s)\n", dataService.GetSourceName())
+	fmt.Printf("F1 %d Drivers (%s)\n", dataService.Season(), dataService.GetSourceName())
 	fmt.Println("══════════════════════════════════════════════")
 
 	for _, driver := range drivers {
@@ -63,13 +75,13 @@ func DriversWithService(args []string, dataService *data.DataService) {
 		if showDetailed {
 			fmt.Printf("\n%d. %s (#%d) - %s\n", driver.ID, driver.Name, driver.Number, driver.Team)
 			fmt.Printf("   Country: %s\n", driver.Country)
-			fmt.Printf("   Points: %d | Wins: %d | Podiums: %d\n", driver.Points, driver.Wins, driver.Podiums)
+			fmt.Printf("   Points: %s | Wins: %d | Podiums: %d\n", data.FormatPoints(driver.Points), driver.Wins, driver.Podiums)
 			if driver.Championships > 0 {
 				fmt.Printf("   Championships: %d\n", driver.Championships)
 			}
 		} else {
-			fmt.Printf("%2d. %-20s #%-2d %-20s %3d pts\n",
-				driver.ID, driver.Name, driver.Number, driver.Team, driver.Points)
+			fmt.Printf("%2d. %-20s #%-2d %-20s %3s pts\n",
+				driver.ID, driver.Name, driver.Number, driver.Team, data.FormatPoints(driver.Points))
 		}
 	}
 
@@ -77,19 +89,32 @@ func DriversWithService(args []string, dataService *data.DataService) {
 		fmt.Printf("\n📋 Filtered by team: %s\n", teamFilter)
 	}
 
-	if dataService.GetSourceName() == "Ergast F1 API" {
-		if !dataService.IsOnline() {
+	if len(drivers) == 0 {
+		if !dataService.IsOnlineContext(ctx) {
 			fmt.Println("\n⚠️  API appears to be offline or unreachable")
 		}
 	}
 }
 
-func showDriverDetail(driver *data.Driver) {
+func showDriverDetail(driver *data.Driver, entry *data.RosterEntry) {
 	fmt.Printf("\n%s (#%d)\n", driver.Name, driver.Number)
 	fmt.Println("══════════════════════════════════════════════")
 	fmt.Printf("Country: %s\n", driver.Country)
 	fmt.Printf("Team: %s\n", driver.Team)
-	fmt.Printf("Championship Points: %d\n", driver.Points)
+	if entry != nil {
+		if teams := entry.Teams(); len(teams) > 1 {
+			fmt.Printf("Raced for: %s\n", strings.Join(teams, " → "))
+		}
+		if first, ok := entry.FirstAppearance(); ok {
+			last, _ := entry.LastAppearance()
+			fmt.Printf("First Appearance: %s %s (%s)\n",
+				first.Session.Location, first.Session.Name, first.Session.DateStart.Format("2006-01-02"))
+			fmt.Printf("Last Appearance: %s %s (%s)\n",
+				last.Session.Location, last.Session.Name, last.Session.DateStart.Format("2006-01-02"))
+			fmt.Printf("Sessions: %d\n", len(entry.Appearances))
+		}
+	}
+	fmt.Printf("Championship Points: %s\n", data.FormatPoints(driver.Points))
 	fmt.Printf("Race Wins: %d\n", driver.Wins)
 	fmt.Printf("Podium Finishes: %d\n", driver.Podiums)
 	if driver.Championships > 0 {
@@ -104,7 +129,8 @@ func ShowDriversHelp() {
 	fmt.Println("  f1 drivers [flags] [driver_name]")
 	fmt.Println()
 	fmt.Println("Arguments:")
-	fmt.Println("  driver_name    Show detailed info for specific driver")
+	fmt.Println("  driver_name    Show detailed info for specific driver: full name, surname,")
+	fmt.Println("                 acronym (VER) or car number; case and accents don't matter")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -d, -detailed      Show detailed information for all drivers")
@@ -116,4 +142,36 @@ func ShowDriversHelp() {
 	fmt.Println("  f1 drivers -d                 # Detailed list")
 	fmt.Println("  f1 drivers -t McLaren         # McLaren drivers only")
 	fmt.Println("  f1 drivers \"Max Verstappen\"   # Specific driver info")
+	fmt.Println("  f1 drivers hulkenberg         # Surnames, acronyms and numbers work too")
+}
+
+// printDriverMatchError explains why a driver query failed, with the
+// candidates to choose from. Without any, it lists drivers when given.
+func printDriverMatchError(err error, drivers []data.Driver) {
+	var matchErr *data.DriverMatchError
+	if !errors.As(err, &matchErr) {
+		fmt.Printf("❌ Error finding driver: %v\n", err)
+		return
+	}
+
+	switch {
+	case matchErr.Ambiguous:
+		fmt.Printf("❌ '%s' matches more than one driver:\n", matchErr.Query)
+		for _, driver := range matchErr.Candidates {
+			fmt.Printf("  - %s (%s, #%d)\n", driver.Name, driver.Code(), driver.Number)
+		}
+	case len(matchErr.Candidates) > 0:
+		fmt.Printf("❌ Driver '%s' not found. Did you mean:\n", matchErr.Query)
+		for _, driver := range matchErr.Candidates {
+			fmt.Printf("  - %s (%s, #%d)\n", driver.Name, driver.Code(), driver.Number)
+		}
+	default:
+		fmt.Printf("❌ Driver '%s' not found\n", matchErr.Query)
+		if len(drivers) > 0 {
+			fmt.Println("\nAvailable drivers:")
+			for _, driver := range drivers {
+				fmt.Printf("  - %s (%s, #%d)\n", driver.Name, driver.Code(), driver.Number)
+			}
+		}
+	}
 }
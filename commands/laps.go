@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for laps command
+const (
+	LapsReset   = "\033[0m"
+	LapsBold    = "\033[1m"
+	LapsRed     = "\033[31m"
+	LapsGreen   = "\033[32m"
+	LapsYellow  = "\033[33m"
+	LapsBlue    = "\033[34m"
+	LapsMagenta = "\033[35m"
+	LapsCyan    = "\033[36m"
+	LapsWhite   = "\033[37m"
+)
+
+// Laps lists every lap a driver ran in a session, coloured like a timing screen
+func Laps(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowLapsHelp()
+		return
+	}
+
+	kind := "Race"
+	round := 0
+	var words []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowLapsHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		default:
+			if name, ok := sessionName(arg); ok {
+				kind = name
+			} else {
+				words = append(words, arg)
+			}
+		}
+	}
+
+	// The first word is the location unless --round already picks the event
+	location := ""
+	if round == 0 && len(words) > 0 {
+		location, words = words[0], words[1:]
+	}
+	driverQuery := strings.Join(words, " ")
+	if (location == "" && round == 0) || driverQuery == "" {
+		ShowLapsHelp()
+		return
+	}
+
+	candidates, err := sessionsNamed(ctx, dataService, kind)
+	if err != nil {
+		fmt.Printf("Error getting sessions: %v\n", err)
+		return
+	}
+	targetSession := selectSession(candidates, location, round, kind, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	if targetSession.State() == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, targetSession.Name,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	entrants, err := dataService.GetEntrantsContext(ctx, *targetSession)
+	if err != nil {
+		fmt.Printf("Error getting drivers: %v\n", err)
+		return
+	}
+	driver, err := data.ResolveDriver(entrants, driverQuery)
+	if err != nil {
+		printDriverMatchError(err, entrants)
+		return
+	}
+
+	// Every driver's laps are needed to find the overall bests
+	allLaps, err := dataService.GetLapsContext(ctx, *targetSession, 0)
+	if err != nil {
+		fmt.Printf("Error getting laps for %s %s: %v\n", targetSession.Location, targetSession.Name, err)
+		return
+	}
+	var laps []data.Lap
+	for _, lap := range allLaps {
+		if lap.DriverNumber == driver.Number {
+			laps = append(laps, lap)
+		}
+	}
+	if len(laps) == 0 {
+		fmt.Printf("%s has no laps in %s %s\n", driver.Name, targetSession.Location, targetSession.Name)
+		return
+	}
+
+	// Tyres are a nice extra, not essential
+	stints, err := dataService.GetStintsContext(ctx, *targetSession, driver.Number)
+	if err != nil {
+		stints = nil
+	}
+
+	overall := bestLapColumns(allLaps)
+	personal := bestLapColumns(laps)
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s%s - %s%s%s\n",
+		LapsBold+LapsYellow, roundLabel, targetSession.Location, targetSession.Name, LapsReset,
+		LapsCyan, targetSession.DateStart.Format("2006-01-02"), LapsReset)
+	fmt.Printf("%s%s%s (%s, #%d)\n", getLapsTeamColor(driver.Team)+LapsBold, driver.Name, LapsReset,
+		driver.Team, driver.Number)
+	fmt.Printf("%s%s%s\n", LapsBold, strings.Repeat("═", 80), LapsReset)
+	if targetSession.State() == data.StateLive {
+		fmt.Printf("%s🔴 LIVE - laps so far%s\n", LapsBold+LapsRed, LapsReset)
+	}
+
+	fmt.Printf("%s%-4s %-10s %-8s %-8s %-8s %-6s %-6s %s%s\n",
+		LapsBold+LapsWhite, "LAP", "TIME", "S1", "S2", "S3", "TRAP", "TYRE", "NOTES", LapsReset)
+	fmt.Printf("%s%s%s\n", LapsBold, strings.Repeat("─", 80), LapsReset)
+
+	for _, lap := range laps {
+		fmt.Printf("%-4d %s", lap.Number, lapCell(data.FormatLapTime(lap.Duration), 10,
+			lap.Duration, personal[0], overall[0]))
+		for sector, t := range lap.Sectors {
+			fmt.Printf(" %s", lapCell(fmt.Sprintf("%.3f", t), 8, t, personal[sector+1], overall[sector+1]))
+		}
+
+		trapText := ""
+		if lap.SpeedTrap > 0 {
+			trapText = strconv.Itoa(lap.SpeedTrap)
+		}
+		fmt.Printf(" %-6s", trapText)
+
+		tyreText, tyreColor := "", LapsReset
+		if stint, ok := data.StintForLap(stints, lap); ok {
+			tyreText = fmt.Sprintf("%s %d", data.CompoundLetter(stint.Compound), stint.TyreAgeAt(lap.Number))
+			tyreColor = getCompoundColor(stint.Compound)
+		}
+		fmt.Printf(" %s%-6s%s", tyreColor, tyreText, LapsReset)
+
+		var notes []string
+		if lap.PitOut {
+			notes = append(notes, "PIT OUT")
+		}
+		if lap.Duration > 0 && lap.Duration == overall[0] {
+			notes = append(notes, "FASTEST LAP")
+		}
+		fmt.Printf(" %s\n", strings.Join(notes, ", "))
+	}
+
+	fmt.Printf("%s%s%s\n", LapsBold, strings.Repeat("─", 80), LapsReset)
+	fmt.Printf("%sBest lap:%s %s", LapsGreen, LapsReset, data.FormatLapTime(personal[0]))
+	if personal[0] > 0 && personal[0] > overall[0] {
+		fmt.Printf(" (+%.3f to the session's fastest)", personal[0]-overall[0])
+	}
+	fmt.Println()
+	if personal[1] > 0 && personal[2] > 0 && personal[3] > 0 {
+		fmt.Printf("%sIdeal lap:%s %s (best three sectors)\n", LapsCyan, LapsReset,
+			data.FormatLapTime(personal[1]+personal[2]+personal[3]))
+	}
+	fmt.Printf("%s■%s fastest of the session   %s■%s personal best\n", LapsMagenta, LapsReset, LapsGreen, LapsReset)
+}
+
+// bestLapColumns returns the fastest lap time and the fastest of each sector among laps
+func bestLapColumns(laps []data.Lap) [4]float64 {
+	var best [4]float64
+	for _, lap := range laps {
+		times := [4]float64{lap.Duration, lap.Sectors[0], lap.Sectors[1], lap.Sectors[2]}
+		for column, t := range times {
+			if t > 0 && (best[column] == 0 || t < best[column]) {
+				best[column] = t
+			}
+		}
+	}
+	return best
+}
+
+// lapCell pads a time to width and colours it purple when it's the session's
+// best and green when it's the driver's
+func lapCell(text string, width int, t, personal, overall float64) string {
+	if t <= 0 {
+		return fmt.Sprintf("%-*s", width, "")
+	}
+	color := LapsReset
+	switch t {
+	case overall:
+		color = LapsMagenta
+	case personal:
+		color = LapsGreen
+	}
+	return fmt.Sprintf("%s%-*s%s", color, width, text, LapsReset)
+}
+
+// getLapsTeamColor returns ANSI color codes for different F1 teams
+func getLapsTeamColor(team string) string {
+	switch team {
+	case "McLaren":
+		return "\033[38;5;208m" // Orange
+	case "Red Bull Racing":
+		return "\033[38;5;27m" // Blue
+	case "Ferrari":
+		return LapsRed
+	case "Mercedes":
+		return "\033[38;5;51m" // Cyan
+	case "Aston Martin":
+		return LapsGreen
+	case "Alpine":
+		return "\033[38;5;129m" // Pink
+	case "Williams":
+		return LapsBlue
+	case "Haas F1 Team", "Haas":
+		return "\033[38;5;245m" // Gray
+	case "Kick Sauber":
+		return "\033[38;5;46m" // Bright Green
+	case "Racing Bulls":
+		return "\033[38;5;63m" // Purple
+	default:
+		return LapsReset
+	}
+}
+
+func ShowLapsHelp() {
+	fmt.Printf("%sF1 Laps%s\n", LapsBold+LapsYellow, LapsReset)
+	fmt.Printf("%s%s%s\n", LapsBold, strings.Repeat("═", 50), LapsReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", LapsBold+LapsGreen, LapsReset)
+	fmt.Printf("  %sf1 laps <location|last|next> <driver> [session]%s\n", LapsCyan, LapsReset)
+	fmt.Printf("  %sf1 laps --round <n> <driver> [session]%s\n", LapsCyan, LapsReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", LapsBold+LapsGreen, LapsReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", LapsYellow, LapsReset)
+	fmt.Printf("  %sdriver%s         Name, surname, acronym or car number\n", LapsYellow, LapsReset)
+	fmt.Printf("  %ssession%s        race (default), sprint, qualifying, fp1, fp2 or fp3\n", LapsYellow, LapsReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", LapsBold+LapsGreen, LapsReset)
+	fmt.Printf("  %sf1 laps Monza LEC%s               # Every lap of Leclerc's race\n", LapsCyan, LapsReset)
+	fmt.Printf("  %sf1 laps Suzuka 1 qualifying%s     # Car #1 in qualifying\n", LapsCyan, LapsReset)
+	fmt.Printf("  %sf1 laps last Norris%s             # Norris in the most recent race\n", LapsCyan, LapsReset)
+	fmt.Println()
+	fmt.Printf("%sColours:%s %spurple%s is the fastest time of the session, %sgreen%s the driver's best\n",
+		LapsBold+LapsMagenta, LapsReset, LapsMagenta, LapsReset, LapsGreen, LapsReset)
+	fmt.Println("      TRAP is the speed trap in km/h; TYRE shows the compound and its age in laps")
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"f1cli/data"
+)
+
+// Penalties lists or validates the stewards' decisions ledger
+func Penalties(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 || args[0] == "-help" || args[0] == "--help" {
+		ShowPenaltiesHelp()
+		return
+	}
+
+	switch args[0] {
+	case "list":
+		listPenalties(dataService)
+	case "validate":
+		path := dataService.Penalties().Path
+		if len(args) > 1 {
+			path = args[1]
+		}
+		validatePenalties(path)
+	default:
+		fmt.Printf("❌ Unknown penalties action: %s\n", args[0])
+		ShowPenaltiesHelp()
+	}
+}
+
+// listPenalties prints the corrections recorded for the selected season
+func listPenalties(dataService *data.DataService) {
+	season := dataService.Season()
+	corrections := dataService.Penalties().ForSeason(season)
+
+	fmt.Printf("Stewards' Decisions %d\n", season)
+	fmt.Println("══════════════════════════════════════════════")
+	if len(corrections) == 0 {
+		fmt.Printf("No corrections recorded for %d\n", season)
+		return
+	}
+
+	for _, correction := range corrections {
+		fmt.Printf("%-10s %-7s #%-3d %s\n",
+			correction.Location, correction.Session, correction.DriverNumber, describeCorrection(correction))
+		fmt.Printf("           %s\n", correction.Reason)
+		if correction.Reference != "" {
+			fmt.Printf("           %s\n", correction.Reference)
+		}
+		if correction.Origin != data.BuiltinLedgerName {
+			fmt.Printf("           (from %s)\n", correction.Origin)
+		}
+	}
+	fmt.Println()
+	fmt.Printf("%d corrections\n", len(corrections))
+}
+
+// describeCorrection summarises what a correction does in a few words
+func describeCorrection(correction data.Correction) string {
+	var summary string
+	switch correction.Type {
+	case data.CorrectionDisqualification:
+		summary = "Disqualified"
+	case data.CorrectionTimePenalty:
+		summary = fmt.Sprintf("+%ss time penalty", data.FormatPoints(correction.Seconds))
+	case data.CorrectionReinstatement:
+		summary = "Reinstated"
+	case data.CorrectionPointsDeduction:
+		summary = fmt.Sprintf("-%s pts", data.FormatPoints(correction.Points))
+	case data.CorrectionPositionChange:
+		summary = "Reclassified"
+	default:
+		summary = correction.Type
+	}
+
+	if correction.Position > 0 {
+		summary += fmt.Sprintf(" → P%d", correction.Position)
+	}
+	return summary
+}
+
+// validatePenalties checks the built-in ledger merged with the override at path
+func validatePenalties(path string) {
+	ledger := data.BuiltinLedger()
+	checked := []string{data.BuiltinLedgerName}
+
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			fmt.Printf("No override ledger at %s\n", path)
+		case err != nil:
+			fmt.Printf("❌ Error reading %s: %v\n", path, err)
+			return
+		default:
+			override, err := data.ParseLedger(raw, path)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				return
+			}
+			ledger.Merge(override)
+			checked = append(checked, path)
+		}
+	}
+
+	problems := ledger.Validate()
+	if len(problems) > 0 {
+		fmt.Printf("❌ %d problems in %s:\n", len(problems), strings.Join(checked, " + "))
+		for _, problem := range problems {
+			fmt.Printf("  - %v\n", problem)
+		}
+		return
+	}
+
+	fmt.Printf("✅ %d corrections OK (%s)\n", len(ledger.Corrections), strings.Join(checked, " + "))
+}
+
+func ShowPenaltiesHelp() {
+	fmt.Println("Inspect the stewards' decisions applied when scoring sessions")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  f1 penalties list")
+	fmt.Println("  f1 penalties validate [file]")
+	fmt.Println()
+	fmt.Println("Actions:")
+	fmt.Println("  list       Show the corrections for the selected season (--season)")
+	fmt.Println("  validate   Check the built-in ledger plus an override file")
+	fmt.Println()
+	fmt.Println("Correction types:")
+	fmt.Println("  disqualification, time_penalty, reinstatement, points_deduction, position_change")
+	fmt.Println()
+	fmt.Println("Overrides are read from --penalties <file>, \"penalties\" in config.json,")
+	fmt.Println("or penalties.json next to config.json. Entries with the same id replace")
+	fmt.Println("built-in ones; every entry needs a reason.")
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -27,146 +28,130 @@ type PointsBreakdown struct {
 	Location    string
 	Date        time.Time
 	SessionType string
+	Team        string
 	Position    int
-	Points      int
-	IsAdjusted  bool // True if position was adjusted due to DSQ
+	Points      float64
+	IsAdjusted  bool // True if the stewards changed the finishing position
+	Note        string
 }
 
 // Points displays detailed race-by-race points breakdown for a specific driver
-func Points(args []string, dataService *data.DataService) {
+func Points(ctx context.Context, args []string, dataService *data.DataService) {
 	if len(args) == 0 {
 		fmt.Println("❌ Error: Please specify a driver name")
-		fmt.Println("Usage: f1 points \"<driver_name>\"")
-		fmt.Println("Example: f1 points \"Oscar Piastri\"")
+		fmt.Println("Usage: f1 points <driver>")
+		fmt.Println("Example: f1 points \"Oscar Piastri\", f1 points PIA, f1 points 81")
 		return
 	}
 
 	targetDriver := strings.Join(args, " ")
-	client := dataService.GetAPIClient()
 
-	// Get all drivers to find the target driver
-	drivers, err := client.GetDrivers()
+	// Search everyone who took part this season, including replaced drivers.
+	// The roster already carries every scored session, so nothing is fetched twice.
+	roster, err := dataService.GetRosterContext(ctx)
 	if err != nil {
 		fmt.Printf("❌ Error fetching drivers: %v\n", err)
 		return
 	}
-
-	var driverNumber int
-	var driverTeam string
-	found := false
-
-	for _, driver := range drivers {
-		if strings.EqualFold(driver.Name, targetDriver) {
-			driverNumber = driver.Number
-			driverTeam = driver.Team
-			found = true
-			break
-		}
+	drivers := make([]data.Driver, len(roster))
+	for i, entry := range roster {
+		drivers[i] = entry.Driver
 	}
 
-	if !found {
-		fmt.Printf("❌ Driver '%s' not found\n", targetDriver)
-		fmt.Println("\nAvailable drivers:")
-		for _, driver := range drivers {
-			fmt.Printf("  - %s\n", driver.Name)
-		}
+	driver, err := data.ResolveDriver(drivers, targetDriver)
+	if err != nil {
+		printDriverMatchError(err, drivers)
 		return
 	}
+	driverNumber := driver.Number
+	driverTeam := driver.Team
+	var appearances []data.Appearance
+	for _, entry := range roster {
+		if entry.Number == driverNumber {
+			appearances = entry.Appearances
+			break
+		}
+	}
 
-	// Get all race and sprint sessions
-	sessions, err := client.GetAllRaceAndSprintSessions()
+	// All race and sprint sessions, to flag live and unclassified ones
+	sessions, err := dataService.GetSessionsContext(ctx)
 	if err != nil {
 		fmt.Printf("❌ Error fetching sessions: %v\n", err)
 		return
 	}
 
 	var pointsBreakdown []PointsBreakdown
-	totalPoints := 0
+	totalPoints := 0.0
+	// Every team the driver raced for this season, in order
+	var teams []string
 	totalWins := 0
 
-	// Process each completed session
-	for _, session := range sessions {
-		// Only process completed sessions
-		if session.DateStart.After(time.Now()) {
+	for _, appearance := range appearances {
+		// Only scored sessions count; practice and qualifying carry no result
+		entry := appearance.Result
+		if entry == nil {
+			continue
+		}
+		session := appearance.Session
+
+		// The team the driver entered this session with
+		team := appearance.Team
+		if team == "" {
+			team = driverTeam
+		}
+		if len(teams) == 0 || teams[len(teams)-1] != team {
+			teams = append(teams, team)
+		}
+
+		if entry.Disqualified {
 			continue
 		}
 
-		results, err := client.GetSessionResults(session.SessionKey)
-		if err != nil {
-			continue // Skip sessions without results
+		// Explain a changed position with the stewards' own reason when there is one
+		note := ""
+		if session.State() == data.StateProvisional {
+			note = "Provisional"
+		} else if len(entry.Corrections) > 0 {
+			note = entry.Corrections[0].Reason
+		} else if entry.Adjusted && entry.ClassifiedPosition < entry.Position {
+			note = fmt.Sprintf("Promoted from P%d after penalties ahead", entry.Position)
 		}
 
-		// Find this driver's result in this session
-		for _, result := range results {
-			if result.DriverNumber == driverNumber {
-				// Check if driver was disqualified
-				dsqDrivers, sessionHasDSQ := data.DisqualifiedDrivers[session.SessionKey]
-				isDisqualified := false
-				if sessionHasDSQ {
-					for _, dsqDriver := range dsqDrivers {
-						if dsqDriver == result.DriverNumber {
-							isDisqualified = true
-							break
-						}
-					}
-				}
-
-				if !isDisqualified {
-					// Determine points system
-					var pointsSystem map[int]int
-					if session.SessionName == "Sprint" {
-						pointsSystem = data.SprintPointsSystem
-					} else {
-						pointsSystem = data.PointsSystem
-					}
-
-					finalPosition := result.Position
-					isAdjusted := false
-
-					// Check for position adjustments
-					if adjustments, hasAdjustments := data.PositionAdjustments[session.SessionKey]; hasAdjustments {
-						if newPosition, wasAdjusted := adjustments[result.DriverNumber]; wasAdjusted {
-							finalPosition = newPosition
-							isAdjusted = true
-						}
-					}
-
-					points := 0
-					if p, hasPoints := pointsSystem[finalPosition]; hasPoints {
-						points = p
-					}
-
-					if points > 0 || isAdjusted {
-						pointsBreakdown = append(pointsBreakdown, PointsBreakdown{
-							RaceName:    session.Location,
-							Location:    session.Location,
-							Date:        session.DateStart,
-							SessionType: session.SessionName,
-							Position:    finalPosition,
-							Points:      points,
-							IsAdjusted:  isAdjusted,
-						})
-
-						totalPoints += points
-					}
-
-					// Count wins (only for main races)
-					if finalPosition == 1 && session.SessionName == "Race" {
-						totalWins++
-					}
-				}
-				break
-			}
+		// A deduction can leave a session at or below zero; it still counts
+		if entry.Points != 0 || entry.Adjusted || len(entry.Corrections) > 0 {
+			pointsBreakdown = append(pointsBreakdown, PointsBreakdown{
+				RaceName:    session.Location,
+				Location:    session.Location,
+				Date:        session.DateStart,
+				SessionType: session.Name,
+				Team:        team,
+				Position:    entry.ClassifiedPosition,
+				Points:      entry.Points,
+				IsAdjusted:  entry.Adjusted,
+				Note:        note,
+			})
+
+			totalPoints += entry.Points
+		}
+
+		if entry.Win {
+			totalWins++
 		}
 	}
 
 	// Display results with enhanced formatting
 	fmt.Printf("%sPoints Breakdown - %s%s %s(#%d)%s\n",
-		PointsBold+PointsYellow, targetDriver, PointsReset, PointsCyan, driverNumber, PointsReset)
+		PointsBold+PointsYellow, driver.Name, PointsReset, PointsCyan, driverNumber, PointsReset)
 
-	// Team color
-	teamColor := getPointsTeamColor(driverTeam)
-	fmt.Printf("%sTeam:%s %s%s%s\n", PointsBlue, PointsReset, teamColor, driverTeam, PointsReset)
+	// Team color; a mid-season move lists every team in order
+	if len(teams) == 0 {
+		teams = []string{driverTeam}
+	}
+	coloredTeams := make([]string, len(teams))
+	for i, team := range teams {
+		coloredTeams[i] = getPointsTeamColor(team) + team + PointsReset
+	}
+	fmt.Printf("%sTeam:%s %s\n", PointsBlue, PointsReset, strings.Join(coloredTeams, " → "))
 
 	// Points summary with colors
 	pointsColor := ""
@@ -183,18 +168,19 @@ func Points(args []string, dataService *data.DataService) {
 		winsColor = PointsBold + PointsYellow
 	}
 
-	fmt.Printf("%sTotal Points:%s %s%d%s %s| Wins:%s %s%d%s\n",
-		PointsBold+PointsBlue, PointsReset, pointsColor, totalPoints, PointsReset,
+	fmt.Printf("%sTotal Points:%s %s%s%s %s| Wins:%s %s%d%s\n",
+		PointsBold+PointsBlue, PointsReset, pointsColor, data.FormatPoints(totalPoints), PointsReset,
 		PointsBold+PointsBlue, PointsReset, winsColor, totalWins, PointsReset)
 	fmt.Printf("%s%s%s\n", PointsBold, strings.Repeat("═", 80), PointsReset)
 
 	if len(pointsBreakdown) == 0 {
-		fmt.Printf("%sNo points scored yet in the 2025 season.%s\n", PointsYellow, PointsReset)
+		fmt.Printf("%sNo points scored yet in the %d season.%s\n", PointsYellow, dataService.Season(), PointsReset)
+		showSessionStateNotes(sessions)
 		return
 	}
 
-	fmt.Printf("%s%-15s %-10s %-8s %-3s %-6s %s%s\n",
-		PointsBold+PointsWhite, "RACE", "DATE", "TYPE", "POS", "POINTS", "NOTES", PointsReset)
+	fmt.Printf("%s%-15s %-10s %-8s %-16s %-3s %-6s %s%s\n",
+		PointsBold+PointsWhite, "RACE", "DATE", "TYPE", "TEAM", "POS", "POINTS", "NOTES", PointsReset)
 	fmt.Printf("%s%s%s\n", PointsBold, strings.Repeat("─", 80), PointsReset)
 
 	for i, breakdown := range pointsBreakdown {
@@ -205,10 +191,9 @@ func Points(args []string, dataService *data.DataService) {
 			sessionColor = PointsYellow
 		}
 
-		notes := ""
+		notes := truncateStringPoints(breakdown.Note, 24)
 		noteColor := PointsReset
 		if breakdown.IsAdjusted {
-			notes = "Promoted due to DSQ"
 			noteColor = PointsCyan
 		}
 
@@ -232,12 +217,13 @@ func Points(args []string, dataService *data.DataService) {
 			pointColor = PointsGreen
 		}
 
-		fmt.Printf("%-15s %s%-10s%s %s%-8s%s %sP%-2d%s %s%-6d%s %s%s%s\n",
+		fmt.Printf("%-15s %s%-10s%s %s%-8s%s %s%-16s%s %sP%-2d%s %s%-6s%s %s%s%s\n",
 			truncateStringPoints(breakdown.RaceName, 15),
 			PointsCyan, breakdown.Date.Format("2006-01-02"), PointsReset,
 			sessionColor, sessionType, PointsReset,
+			getPointsTeamColor(breakdown.Team), truncateStringPoints(breakdown.Team, 16), PointsReset,
 			posColor, breakdown.Position, PointsReset,
-			pointColor, breakdown.Points, PointsReset,
+			pointColor, data.FormatPoints(breakdown.Points), PointsReset,
 			noteColor, notes, PointsReset)
 
 		// Add separator after every 5 races for readability
@@ -249,11 +235,13 @@ func Points(args []string, dataService *data.DataService) {
 	fmt.Printf("%s%s%s\n", PointsBold, strings.Repeat("─", 80), PointsReset)
 	fmt.Printf("%sPoints scored in %d/%d sessions%s\n",
 		PointsBold+PointsCyan, len(pointsBreakdown), countCompletedSessions(sessions), PointsReset)
+	showSessionStateNotes(sessions)
 
 	// Show points system info with colors
-	fmt.Printf("\n%sPoints Systems:%s\n", PointsBold+PointsBlue, PointsReset)
-	fmt.Printf("   %sRace:%s   25-18-15-12-10-8-6-4-2-1 (positions 1-10)\n", PointsGreen, PointsReset)
-	fmt.Printf("   %sSprint:%s 8-7-6-5-4-3-2-1 (positions 1-8)\n", PointsYellow, PointsReset)
+	fmt.Printf("\n%sPoints Systems (%d):%s\n", PointsBold+PointsBlue, dataService.Season(), PointsReset)
+	for _, line := range describeRegulations(dataService.Season()) {
+		fmt.Printf("   %s\n", line)
+	}
 }
 
 // getPointsTeamColor returns ANSI color codes for different F1 teams
@@ -292,11 +280,11 @@ func truncateStringPoints(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// countCompletedSessions counts how many sessions have been completed
-func countCompletedSessions(sessions []data.OpenF1Session) int {
+// countCompletedSessions counts how many sessions have ended
+func countCompletedSessions(sessions []data.Session) int {
 	count := 0
 	for _, session := range sessions {
-		if session.DateStart.Before(time.Now()) {
+		if session.HasEnded() {
 			count++
 		}
 	}
@@ -309,14 +297,18 @@ func ShowPointsHelp() {
 	fmt.Printf("%s%s%s\n", PointsBold, strings.Repeat("═", 50), PointsReset)
 	fmt.Println()
 	fmt.Printf("%sUsage:%s\n", PointsBold+PointsGreen, PointsReset)
-	fmt.Printf("  %sf1 points \"<driver_name>\"%s\n", PointsCyan, PointsReset)
+	fmt.Printf("  %sf1 points <driver>%s\n", PointsCyan, PointsReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", PointsBold+PointsGreen, PointsReset)
+	fmt.Printf("  %sdriver%s         Name, surname, acronym or car number\n", PointsYellow, PointsReset)
 	fmt.Println()
 	fmt.Printf("%sDescription:%s\n", PointsBold+PointsGreen, PointsReset)
 	fmt.Printf("  Shows a detailed breakdown of points scored by a specific driver\n")
-	fmt.Printf("  in each race and sprint session of the current season.\n")
+	fmt.Printf("  in each race and sprint session of the selected season (--season).\n")
 	fmt.Println()
 	fmt.Printf("%sFeatures:%s\n", PointsBold+PointsGreen, PointsReset)
 	fmt.Printf("  %s•%s Race-by-race points breakdown\n", PointsBlue, PointsReset)
+	fmt.Printf("  %s•%s The team raced for in each session\n", PointsCyan, PointsReset)
 	fmt.Printf("  %s•%s Sprint session points\n", PointsYellow, PointsReset)
 	fmt.Printf("  %s•%s Position adjustments due to disqualifications\n", PointsRed, PointsReset)
 	fmt.Printf("  %s•%s Total points and wins summary\n", PointsGreen, PointsReset)
@@ -324,10 +316,10 @@ func ShowPointsHelp() {
 	fmt.Println()
 	fmt.Printf("%sExamples:%s\n", PointsBold+PointsGreen, PointsReset)
 	fmt.Printf("  %sf1 points \"Oscar Piastri\"%s     # Show Oscar's points breakdown\n", PointsCyan, PointsReset)
-	fmt.Printf("  %sf1 points \"Lewis Hamilton\"%s    # Show Lewis's points breakdown\n", PointsCyan, PointsReset)
-	fmt.Printf("  %sf1 points \"Max Verstappen\"%s    # Show Max's points breakdown\n", PointsCyan, PointsReset)
+	fmt.Printf("  %sf1 points Hamilton%s            # Surnames work too\n", PointsCyan, PointsReset)
+	fmt.Printf("  %sf1 points VER%s                 # As do acronyms and car numbers\n", PointsCyan, PointsReset)
 	fmt.Println()
 	fmt.Printf("%sNote:%s\n", PointsBold+PointsMagenta, PointsReset)
-	fmt.Printf("  Driver names are case-insensitive and should match the full name\n")
-	fmt.Printf("  as shown in the drivers list (%sf1 drivers%s).\n", PointsCyan, PointsReset)
+	fmt.Printf("  Case and accents don't matter. Everyone who took part in the season\n")
+	fmt.Printf("  can be looked up, including replaced drivers (%sf1 drivers%s).\n", PointsCyan, PointsReset)
 }
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for practice command
+const (
+	PracticeReset   = "\033[0m"
+	PracticeBold    = "\033[1m"
+	PracticeRed     = "\033[31m"
+	PracticeGreen   = "\033[32m"
+	PracticeYellow  = "\033[33m"
+	PracticeBlue    = "\033[34m"
+	PracticeMagenta = "\033[35m"
+	PracticeCyan    = "\033[36m"
+	PracticeWhite   = "\033[37m"
+)
+
+// Practice shows a practice session's timesheet and each driver's long-run pace
+func Practice(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowPracticeHelp()
+		return
+	}
+
+	location := ""
+	number := 0
+	round := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowPracticeHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		case practiceNumber(arg) > 0:
+			number = practiceNumber(arg)
+		case location == "":
+			location = arg
+		}
+	}
+
+	if location == "" && round == 0 {
+		ShowPracticeHelp()
+		return
+	}
+
+	sessions, err := dataService.GetWeekendSessionsContext(ctx, "Practice")
+	if err != nil {
+		fmt.Printf("Error getting practice sessions: %v\n", err)
+		return
+	}
+
+	kind := "Practice"
+	var candidates []data.Session
+	for _, session := range sessions {
+		if number == 0 || session.Name == fmt.Sprintf("Practice %d", number) {
+			candidates = append(candidates, session)
+		}
+	}
+	if number > 0 {
+		kind = fmt.Sprintf("Practice %d", number)
+	}
+	targetSession := selectSession(candidates, location, round, kind, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	// Without a session number, show the meeting's latest practice so far
+	if number == 0 && !strings.EqualFold(location, "next") {
+		for i, session := range sessions {
+			if session.MeetingKey == targetSession.MeetingKey && session.State() != data.StateUpcoming {
+				targetSession = &sessions[i]
+			}
+		}
+	}
+
+	state := targetSession.State()
+	if state == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, targetSession.Name,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	laps, err := dataService.GetLapsContext(ctx, *targetSession, 0)
+	if err != nil {
+		fmt.Printf("Error getting laps for %s %s: %v\n", targetSession.Location, targetSession.Name, err)
+		return
+	}
+	if len(laps) == 0 {
+		fmt.Printf("No laps have been run in %s %s yet\n", targetSession.Location, targetSession.Name)
+		return
+	}
+
+	// Tyres, flags and names make the picture richer but aren't essential
+	stints, err := dataService.GetStintsContext(ctx, *targetSession, 0)
+	if err != nil {
+		stints = nil
+	}
+	messages, flagsErr := dataService.GetRaceControlContext(ctx, *targetSession)
+	neutralised := data.NeutralisedPeriods(messages, targetSession.End())
+
+	driverNames := make(map[int]string)
+	driverTeams := make(map[int]string)
+	if entrants, err := dataService.GetEntrantsContext(ctx, *targetSession); err == nil {
+		for _, driver := range entrants {
+			driverNames[driver.Number] = driver.Name
+			driverTeams[driver.Number] = driver.Team
+		}
+	}
+	driverName := func(number int) string {
+		if name := driverNames[number]; name != "" {
+			return name
+		}
+		return fmt.Sprintf("Driver #%d", number)
+	}
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s%s - %s%s%s\n",
+		PracticeBold+PracticeYellow, roundLabel, targetSession.Location, targetSession.Name, PracticeReset,
+		PracticeCyan, targetSession.DateStart.Format("2006-01-02"), PracticeReset)
+	fmt.Printf("%s%s%s\n", PracticeBold, strings.Repeat("═", 80), PracticeReset)
+	if state == data.StateLive {
+		fmt.Printf("%s🔴 LIVE - times so far%s\n", PracticeBold+PracticeRed, PracticeReset)
+	}
+
+	fmt.Printf("%s%-3s %-22s %-18s %-10s %-8s %-5s %-10s%s\n",
+		PracticeBold+PracticeWhite, "POS", "DRIVER", "TEAM", "BEST", "GAP", "LAPS", "TYRES", PracticeReset)
+	fmt.Printf("%s%s%s\n", PracticeBold, strings.Repeat("─", 80), PracticeReset)
+
+	results := data.SummarisePractice(laps, stints)
+	leader := results[0].Best
+	for i, result := range results {
+		posColor := PracticeReset
+		if i == 0 {
+			posColor = PracticeBold + PracticeYellow
+		}
+
+		bestText := data.FormatLapTime(result.Best)
+		if result.BestCompound != "" {
+			bestText += " " + data.CompoundLetter(result.BestCompound)
+		}
+		gapText := ""
+		if i > 0 && result.Best > 0 {
+			gapText = fmt.Sprintf("+%.3f", result.Best-leader)
+		}
+
+		tyres := make([]string, len(result.Compounds))
+		for j, compound := range result.Compounds {
+			tyres[j] = getCompoundColor(compound) + data.CompoundLetter(compound) + PracticeReset
+		}
+
+		team := driverTeams[result.DriverNumber]
+		fmt.Printf("%s%-3d%s %-22s %s%-18s%s %-10s %-8s %-5d %s\n",
+			posColor, i+1, PracticeReset,
+			truncateString(driverName(result.DriverNumber), 22),
+			getPracticeTeamColor(team), truncateString(team, 18), PracticeReset,
+			bestText, gapText, result.Laps, strings.Join(tyres, " "))
+	}
+
+	fmt.Printf("\n%sLong runs%s (%d+ consecutive green-flag laps on one set, each driver's longest)\n",
+		PracticeBold+PracticeYellow, PracticeReset, data.LongRunMinLaps)
+	fmt.Printf("%s%s%s\n", PracticeBold, strings.Repeat("─", 80), PracticeReset)
+
+	runs := data.BestLongRuns(data.FindLongRuns(laps, stints, neutralised))
+	if len(runs) == 0 {
+		fmt.Println("No long runs in this session")
+	} else {
+		fmt.Printf("%s%-3s %-22s %-5s %-9s %-10s %-8s %-10s%s\n",
+			PracticeBold+PracticeWhite, "#", "DRIVER", "TYRE", "LAPS", "AVERAGE", "GAP", "RANGE", PracticeReset)
+		for i, run := range runs {
+			gapText := ""
+			if i > 0 {
+				gapText = fmt.Sprintf("+%.3f", run.Average-runs[0].Average)
+			}
+			first, last := run.Laps[0].Number, run.Laps[len(run.Laps)-1].Number
+			fmt.Printf("%-3d %-22s %s%-5s%s %-9d %-10s %-8s L%d-%d\n",
+				i+1, truncateString(driverName(run.DriverNumber), 22),
+				getCompoundColor(run.Compound), data.CompoundLetter(run.Compound), PracticeReset,
+				len(run.Laps), data.FormatLapTime(run.Average), gapText, first, last)
+		}
+		fmt.Println("\nRuns on different compounds or fuel loads aren't like for like")
+	}
+
+	if flagsErr != nil {
+		fmt.Printf("%sNote:%s race control messages unavailable (%v); yellow flags aren't excluded\n",
+			PracticeMagenta, PracticeReset, flagsErr)
+	} else if len(neutralised) > 0 {
+		fmt.Printf("%sExcluded:%s %s\n", PracticeCyan, PracticeReset, describeNeutralised(neutralised))
+	}
+}
+
+// practiceNumber reads a practice session number: "2" or "fp2"
+func practiceNumber(arg string) int {
+	arg = strings.TrimPrefix(strings.ToLower(arg), "fp")
+	if n, err := strconv.Atoi(arg); err == nil && n >= 1 && n <= 3 {
+		return n
+	}
+	return 0
+}
+
+// describeNeutralised summarises how often running was neutralised, e.g. "1 × red flag, 3 × yellow"
+func describeNeutralised(periods []data.Interval) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, period := range periods {
+		reason := period.Reason
+		if strings.HasPrefix(reason, "Yellow") {
+			reason = "Yellow"
+		}
+		if counts[reason] == 0 {
+			order = append(order, reason)
+		}
+		counts[reason]++
+	}
+
+	parts := make([]string, len(order))
+	for i, reason := range order {
+		parts[i] = fmt.Sprintf("%d × %s", counts[reason], strings.ToLower(reason))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getCompoundColor returns the ANSI color Pirelli uses for a compound
+func getCompoundColor(compound string) string {
+	switch strings.ToUpper(compound) {
+	case "SOFT":
+		return PracticeRed
+	case "MEDIUM":
+		return PracticeYellow
+	case "HARD":
+		return PracticeWhite
+	case "INTERMEDIATE":
+		return PracticeGreen
+	case "WET":
+		return PracticeBlue
+	default:
+		return PracticeReset
+	}
+}
+
+// getPracticeTeamColor returns ANSI color codes for different F1 teams
+func getPracticeTeamColor(team string) string {
+	switch team {
+	case "McLaren":
+		return "\033[38;5;208m" // Orange
+	case "Red Bull Racing":
+		return "\033[38;5;27m" // Blue
+	case "Ferrari":
+		return PracticeRed
+	case "Mercedes":
+		return "\033[38;5;51m" // Cyan
+	case "Aston Martin":
+		return PracticeGreen
+	case "Alpine":
+		return "\033[38;5;129m" // Pink
+	case "Williams":
+		return PracticeBlue
+	case "Haas F1 Team", "Haas":
+		return "\033[38;5;245m" // Gray
+	case "Kick Sauber":
+		return "\033[38;5;46m" // Bright Green
+	case "Racing Bulls":
+		return "\033[38;5;63m" // Purple
+	default:
+		return PracticeReset
+	}
+}
+
+func ShowPracticeHelp() {
+	fmt.Printf("%sF1 Practice%s\n", PracticeBold+PracticeYellow, PracticeReset)
+	fmt.Printf("%s%s%s\n", PracticeBold, strings.Repeat("═", 50), PracticeReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", PracticeBold+PracticeGreen, PracticeReset)
+	fmt.Printf("  %sf1 practice <location|last|next> [1|2|3]%s\n", PracticeCyan, PracticeReset)
+	fmt.Printf("  %sf1 practice --round <n> [1|2|3]%s\n", PracticeCyan, PracticeReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", PracticeBold+PracticeGreen, PracticeReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", PracticeYellow, PracticeReset)
+	fmt.Printf("  %s1, 2, 3%s        Which practice session (also fp1-fp3); default: the latest run\n", PracticeYellow, PracticeReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", PracticeBold+PracticeGreen, PracticeReset)
+	fmt.Printf("  %sf1 practice Silverstone 2%s     # FP2 timesheet and long runs\n", PracticeCyan, PracticeReset)
+	fmt.Printf("  %sf1 practice last%s              # The most recent practice session\n", PracticeCyan, PracticeReset)
+	fmt.Println()
+	fmt.Printf("%sLong runs:%s %d or more consecutive laps on one set of tyres, with no out or in\n",
+		PracticeBold+PracticeMagenta, PracticeReset, data.LongRunMinLaps)
+	fmt.Println("      laps, no red or yellow flags or safety car, and none more than 5% slower")
+	fmt.Println("      than the stint's typical lap. Each driver's longest run is ranked.")
+}
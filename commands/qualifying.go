@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for qualifying command
+const (
+	QualifyingReset   = "\033[0m"
+	QualifyingBold    = "\033[1m"
+	QualifyingRed     = "\033[31m"
+	QualifyingGreen   = "\033[32m"
+	QualifyingYellow  = "\033[33m"
+	QualifyingBlue    = "\033[34m"
+	QualifyingMagenta = "\033[35m"
+	QualifyingCyan    = "\033[36m"
+	QualifyingWhite   = "\033[37m"
+)
+
+// Qualifying shows a qualifying session part by part, with the knockouts
+func Qualifying(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowQualifyingHelp()
+		return
+	}
+
+	location := ""
+	sprint := false
+	round := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowQualifyingHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		case strings.EqualFold(arg, "sprint"):
+			sprint = true
+		case location == "":
+			location = arg
+		}
+	}
+
+	if location == "" && round == 0 {
+		ShowQualifyingHelp()
+		return
+	}
+
+	sessions, err := dataService.GetWeekendSessionsContext(ctx, "Qualifying")
+	if err != nil {
+		fmt.Printf("Error getting qualifying sessions: %v\n", err)
+		return
+	}
+
+	kind := "Qualifying"
+	if sprint {
+		kind = "Sprint Qualifying"
+	}
+	var candidates []data.Session
+	for _, session := range sessions {
+		if data.IsSprintQualifying(session) == sprint {
+			candidates = append(candidates, session)
+		}
+	}
+	targetSession := selectSession(candidates, location, round, kind, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	state := targetSession.State()
+	if state == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, targetSession.Name,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	results, err := dataService.GetQualifyingContext(ctx, *targetSession)
+	if err != nil {
+		fmt.Printf("Error getting %s for %s: %v\n", targetSession.Name, targetSession.Location, err)
+		return
+	}
+	if len(results) == 0 {
+		fmt.Printf("No times have been set in %s %s yet\n", targetSession.Location, targetSession.Name)
+		return
+	}
+
+	// Unclassified drivers go last
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Position == 0) != (results[j].Position == 0) {
+			return results[j].Position == 0
+		}
+		return results[i].Position < results[j].Position
+	})
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s%s - %s%s%s\n",
+		QualifyingBold+QualifyingYellow, roundLabel, targetSession.Location, targetSession.Name, QualifyingReset,
+		QualifyingCyan, targetSession.DateStart.Format("2006-01-02"), QualifyingReset)
+	fmt.Printf("%s%s%s\n", QualifyingBold, strings.Repeat("═", 92), QualifyingReset)
+
+	switch state {
+	case data.StateLive:
+		fmt.Printf("%s🔴 LIVE - times so far; the knockouts may not have happened yet%s\n",
+			QualifyingBold+QualifyingRed, QualifyingReset)
+	case data.StateProvisional:
+		fmt.Printf("%s⏳ Provisional - grid penalties and deleted laps may still change this%s\n",
+			QualifyingBold+QualifyingYellow, QualifyingReset)
+	}
+
+	segments := data.SegmentNames(*targetSession)
+	fmt.Printf("%s%-3s %-22s %-18s %-9s %-9s %-9s %-8s %-7s%s\n",
+		QualifyingBold+QualifyingWhite, "POS", "DRIVER", "TEAM", segments[0], segments[1], segments[2],
+		"GAP", "TM", QualifyingReset)
+	fmt.Printf("%s%s%s\n", QualifyingBold, strings.Repeat("─", 92), QualifyingReset)
+
+	// The fastest time of each part is shown in purple, as on timing screens
+	var fastest [3]float64
+	for _, result := range results {
+		for segment, t := range result.Times {
+			if t > 0 && (fastest[segment] == 0 || t < fastest[segment]) {
+				fastest[segment] = t
+			}
+		}
+	}
+
+	pole := results[0].Best()
+	deltas := data.TeammateDeltas(results)
+	toSecond, toThird := data.QualifyingAdvance(len(results))
+
+	for i, result := range results {
+		driverName := result.Driver
+		if driverName == "" {
+			driverName = fmt.Sprintf("Driver #%d", result.DriverNumber)
+		}
+
+		posColor := QualifyingReset
+		switch {
+		case result.Position == 1:
+			posColor = QualifyingBold + QualifyingYellow
+		case result.Segment == 3:
+			posColor = QualifyingGreen
+		}
+		positionText := "-"
+		if result.Position > 0 {
+			positionText = strconv.Itoa(result.Position)
+		}
+
+		fmt.Printf("%s%-3s%s %-22s %s%-18s%s",
+			posColor, positionText, QualifyingReset,
+			truncateString(driverName, 22),
+			getQualifyingTeamColor(result.Team), truncateString(result.Team, 18), QualifyingReset)
+
+		for segment, t := range result.Times {
+			timeText, timeColor := data.FormatLapTime(t), QualifyingReset
+			switch {
+			case t > 0 && t == fastest[segment]:
+				timeColor = QualifyingMagenta
+			case t == 0 && segment < result.Segment:
+				timeText, timeColor = "no time", QualifyingRed
+			}
+			fmt.Printf(" %s%-9s%s", timeColor, timeText, QualifyingReset)
+		}
+
+		// Gap to pole compares each driver's fastest lap of the session
+		gapText := ""
+		switch best := result.Best(); {
+		case i == 0 && result.Position == 1:
+			gapText = "POLE"
+		case best > 0 && pole > 0:
+			gapText = fmt.Sprintf("+%.3f", best-pole)
+		}
+		fmt.Printf(" %-8s", gapText)
+
+		if delta, ok := deltas[result.DriverNumber]; ok {
+			deltaColor := QualifyingGreen
+			if delta > 0 {
+				deltaColor = QualifyingRed
+			}
+			fmt.Printf(" %s%+.3f%s", deltaColor, delta, QualifyingReset)
+		}
+		fmt.Println()
+
+		// Knockout lines under the last driver through to each part
+		if toThird < len(results) && result.Position == toThird {
+			fmt.Printf("%s┄┄ knocked out in %s ┄┄%s%s\n", QualifyingCyan, segments[1],
+				strings.Repeat("┄", 92-len(segments[1])-22), QualifyingReset)
+		} else if toSecond < len(results) && result.Position == toSecond {
+			fmt.Printf("%s┄┄ knocked out in %s ┄┄%s%s\n", QualifyingMagenta, segments[0],
+				strings.Repeat("┄", 92-len(segments[0])-22), QualifyingReset)
+		}
+	}
+
+	fmt.Println()
+	if toSecond < len(results) {
+		fmt.Printf("%sKnockouts:%s top %d through to %s, top %d to %s\n", QualifyingGreen, QualifyingReset,
+			toSecond, segments[1], toThird, segments[2])
+	}
+	fmt.Printf("%sGAP%s is each driver's fastest lap against pole; %sTM%s is the gap to their teammate\n",
+		QualifyingBold, QualifyingReset, QualifyingBold, QualifyingReset)
+	fmt.Println("in the last part they both set a time in")
+}
+
+// getQualifyingTeamColor returns ANSI color codes for different F1 teams
+func getQualifyingTeamColor(team string) string {
+	switch team {
+	case "McLaren":
+		return "\033[38;5;208m" // Orange
+	case "Red Bull Racing":
+		return "\033[38;5;27m" // Blue
+	case "Ferrari":
+		return QualifyingRed
+	case "Mercedes":
+		return "\033[38;5;51m" // Cyan
+	case "Aston Martin":
+		return QualifyingGreen
+	case "Alpine":
+		return "\033[38;5;129m" // Pink
+	case "Williams":
+		return QualifyingBlue
+	case "Haas F1 Team", "Haas":
+		return "\033[38;5;245m" // Gray
+	case "Kick Sauber":
+		return "\033[38;5;46m" // Bright Green
+	case "Racing Bulls":
+		return "\033[38;5;63m" // Purple
+	default:
+		return QualifyingReset
+	}
+}
+
+func ShowQualifyingHelp() {
+	fmt.Printf("%sF1 Qualifying%s\n", QualifyingBold+QualifyingYellow, QualifyingReset)
+	fmt.Printf("%s%s%s\n", QualifyingBold, strings.Repeat("═", 50), QualifyingReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", QualifyingBold+QualifyingGreen, QualifyingReset)
+	fmt.Printf("  %sf1 qualifying <location|last|next> [sprint]%s\n", QualifyingCyan, QualifyingReset)
+	fmt.Printf("  %sf1 qualifying --round <n> [sprint]%s\n", QualifyingCyan, QualifyingReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", QualifyingBold+QualifyingGreen, QualifyingReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", QualifyingYellow, QualifyingReset)
+	fmt.Printf("  %slast, next%s     The most recent finished session, or the next one to run\n", QualifyingYellow, QualifyingReset)
+	fmt.Printf("  %s--round <n>%s    Championship round\n", QualifyingYellow, QualifyingReset)
+	fmt.Printf("  %ssprint%s         Sprint qualifying (the Sprint Shootout in 2023)\n", QualifyingYellow, QualifyingReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", QualifyingBold+QualifyingGreen, QualifyingReset)
+	fmt.Printf("  %sf1 qualifying Monaco%s          # Grid for the Monaco Grand Prix\n", QualifyingCyan, QualifyingReset)
+	fmt.Printf("  %sf1 qualifying Miami sprint%s    # Grid for the Miami sprint\n", QualifyingCyan, QualifyingReset)
+	fmt.Printf("  %sf1 qualifying last%s            # The most recent qualifying\n", QualifyingCyan, QualifyingReset)
+	fmt.Println()
+	fmt.Printf("%sNote:%s Until the official classification is published, the parts are\n",
+		QualifyingBold+QualifyingMagenta, QualifyingReset)
+	fmt.Println("      worked out from lap times and deleted laps still count")
+}
@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"context"
 	"f1cli/data"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -19,7 +21,7 @@ const (
 	ResultsWhite   = "\033[37m"
 )
 
-func Results(args []string, dataService *data.DataService) {
+func Results(ctx context.Context, args []string, dataService *data.DataService) {
 	if len(args) == 0 {
 		ShowResultsHelp()
 		return
@@ -33,60 +35,107 @@ func Results(args []string, dataService *data.DataService) {
 		}
 	}
 
-	location := args[0]
+	location := ""
 	sessionType := "Race" // Default to race
+	round := 0
 
-	// Check if sprint is specified
-	if len(args) > 1 && args[1] == "sprint" {
-		sessionType = "Sprint"
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		case strings.EqualFold(arg, "sprint"):
+			sessionType = "Sprint"
+		case strings.EqualFold(arg, "race"):
+			sessionType = "Race"
+		case location == "":
+			location = arg
+		}
 	}
 
-	client := data.NewAPIClient()
+	if location == "" && round == 0 {
+		ShowResultsHelp()
+		return
+	}
 
-	sessions, err := client.GetAllRaceAndSprintSessions()
+	sessions, err := dataService.GetSessionsContext(ctx)
 	if err != nil {
 		fmt.Printf("Error getting sessions: %v\n", err)
 		return
 	}
 
-	var targetSession *data.OpenF1Session
+	var candidates []data.Session
 	for _, session := range sessions {
-		// Match by location (case-insensitive) and session type
-		if contains(session.Location, location) && session.SessionName == sessionType {
-			targetSession = &session
-			break
+		if session.Name == sessionType {
+			candidates = append(candidates, session)
 		}
 	}
-
+	targetSession := selectSession(candidates, location, round, sessionType, dataService.Season())
 	if targetSession == nil {
-		fmt.Printf("No %s session found for location: %s\n", sessionType, location)
-		fmt.Println("\nAvailable locations:")
-		seen := make(map[string]bool)
-		for _, session := range sessions {
-			if !seen[session.Location] {
-				fmt.Printf("  - %s\n", session.Location)
-				seen[session.Location] = true
-			}
-		}
 		return
 	}
 
-	results, err := client.GetSessionResults(targetSession.SessionKey)
+	state := targetSession.State()
+	if state == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, sessionType,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	results, err := dataService.GetClassificationContext(ctx, *targetSession)
 	if err != nil {
-		fmt.Printf("Error getting results for %s %s: %v\n", location, sessionType, err)
+		fmt.Printf("Error getting results for %s %s: %v\n", targetSession.Location, sessionType, err)
+		return
+	}
+
+	// The fastest lap is shown even in seasons where it scores nothing; it's
+	// left out when the source has no lap data. Marking the classification
+	// above usually found it already, so this rarely downloads anything.
+	fastest, err := dataService.GetFastestLapContext(ctx, *targetSession)
+	if err != nil && !data.IsNoData(err) {
+		fmt.Printf("Error getting the fastest lap for %s %s: %v\n", targetSession.Location, sessionType, err)
 		return
 	}
 
-	// Sort results by position
-	for i := 0; i < len(results)-1; i++ {
-		for j := i + 1; j < len(results); j++ {
-			if results[j].Position < results[i].Position {
-				results[i], results[j] = results[j], results[i]
+	// Rain puts odd results into context; the tyres show who ran on wets.
+	// Both are left out when the source has no weather or tyre data.
+	samples, err := dataService.GetWeatherContext(ctx, *targetSession)
+	if err != nil && !data.IsNoData(err) {
+		fmt.Printf("Error getting weather for %s %s: %v\n", targetSession.Location, sessionType, err)
+		return
+	}
+	weather := data.SummariseWeather(samples)
+	wetTyres := make(map[int][]string)
+	if weather.Wet() {
+		stints, err := dataService.GetStintsContext(ctx, *targetSession, 0)
+		if err != nil && !data.IsNoData(err) {
+			fmt.Printf("Error getting tyre stints for %s %s: %v\n", targetSession.Location, sessionType, err)
+			return
+		}
+		for _, stint := range stints {
+			compound := strings.ToUpper(stint.Compound)
+			if (compound == "INTERMEDIATE" || compound == "WET") &&
+				!containsString(wetTyres[stint.DriverNumber], data.CompoundLetter(compound)) {
+				wetTyres[stint.DriverNumber] = append(wetTyres[stint.DriverNumber], data.CompoundLetter(compound))
 			}
 		}
 	}
 
-	drivers, err := client.GetDrivers()
+	drivers, err := dataService.GetSeasonDriversContext(ctx)
 	if err != nil {
 		fmt.Printf("Error getting drivers: %v\n", err)
 		return
@@ -105,85 +154,109 @@ func Results(args []string, dataService *data.DataService) {
 		sessionIcon = "Sprint"
 	}
 
-	fmt.Printf("%s%s %s %s Results%s - %s%s%s\n",
-		ResultsBold+ResultsYellow, sessionIcon, targetSession.Location, sessionType, ResultsReset,
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s %s Results%s - %s%s%s\n",
+		ResultsBold+ResultsYellow, roundLabel, sessionIcon, targetSession.Location, sessionType, ResultsReset,
 		ResultsCyan, targetSession.DateStart.Format("2006-01-02"), ResultsReset)
 	fmt.Printf("%s%s%s\n", ResultsBold, strings.Repeat("═", 80), ResultsReset)
 
-	fmt.Printf("%s%-3s %-25s %-20s %-8s%s\n",
-		ResultsBold+ResultsWhite, "POS", "DRIVER", "TEAM", "NUMBER", ResultsReset)
+	switch state {
+	case data.StateLive:
+		fmt.Printf("%s🔴 LIVE - running order so far; not counted in the standings yet%s\n",
+			ResultsBold+ResultsRed, ResultsReset)
+	case data.StateProvisional:
+		fmt.Printf("%s⏳ Provisional - stewards' decisions may still change this result%s\n",
+			ResultsBold+ResultsYellow, ResultsReset)
+	}
+	if weather.Wet() {
+		fmt.Printf("%s🌧  Wet %s - rain in %.0f%% of weather readings%s\n",
+			ResultsBold+ResultsBlue, strings.ToLower(sessionType), weather.RainShare()*100, ResultsReset)
+	}
+
+	fmt.Printf("%s%-3s %-4s %-25s %-20s %-7s %-11s%s\n",
+		ResultsBold+ResultsWhite, "POS", "ORIG", "DRIVER", "TEAM", "NUMBER", "GAP", ResultsReset)
 	fmt.Printf("%s%s%s\n", ResultsBold, strings.Repeat("─", 80), ResultsReset)
 
-	// Determine points system
-	var pointsSystem map[int]int
-	if sessionType == "Sprint" {
-		pointsSystem = data.SprintPointsSystem
-	} else {
-		pointsSystem = data.PointsSystem
-	}
+	// Score the session under its season's regulations; disqualified drivers
+	// are dropped and the rest of the field moves up
+	scoredResults := dataService.ScoreSession(*targetSession, results)
+	pointsPositions := len(data.RegulationsFor(targetSession.Season()).PointsScale(*targetSession))
+
+	for i, scored := range scoredResults {
+		result := scored.SessionResult
 
-	for i, result := range results {
-		driverName := driverNames[result.DriverNumber]
+		// Prefer names reported with the result; fall back to the season roster
+		driverName := result.Driver
+		if driverName == "" {
+			driverName = driverNames[result.DriverNumber]
+		}
 		if driverName == "" {
 			driverName = fmt.Sprintf("Driver #%d", result.DriverNumber)
 		}
 
-		teamName := driverTeams[result.DriverNumber]
+		teamName := result.Team
 		if teamName == "" {
-			teamName = "Unknown Team"
+			teamName = driverTeams[result.DriverNumber]
 		}
-
-		// Check if driver was disqualified
-		dsqDrivers, sessionHasDSQ := data.DisqualifiedDrivers[targetSession.SessionKey]
-		isDisqualified := false
-		if sessionHasDSQ {
-			for _, dsqDriver := range dsqDrivers {
-				if dsqDriver == result.DriverNumber {
-					isDisqualified = true
-					break
-				}
-			}
+		if teamName == "" {
+			teamName = "Unknown Team"
 		}
 
-		points := 0
-		if !isDisqualified {
-			finalPosition := result.Position
-
-			// Check if driver's position was adjusted due to DSQs
-			if adjustments, hasAdjustments := data.PositionAdjustments[targetSession.SessionKey]; hasAdjustments {
-				if newPosition, wasAdjusted := adjustments[result.DriverNumber]; wasAdjusted {
-					finalPosition = newPosition
-				}
-			}
-
-			if p, hasPoints := pointsSystem[finalPosition]; hasPoints {
-				points = p
-			}
-		}
+		isDisqualified := scored.Disqualified
+		position := scored.ClassifiedPosition
+		points := scored.Points
 
 		// Color coding for positions
 		var posColor string
 		switch {
-		case result.Position == 1:
+		case isDisqualified:
+			posColor = ResultsRed // Red for DSQ
+		case position == 1:
 			posColor = ResultsBold + ResultsYellow // Gold for winner
-		case result.Position <= 3:
+		case position <= 3:
 			posColor = ResultsBold + ResultsWhite // Silver/Bronze for podium
-		case result.Position <= 10 && !isDisqualified:
+		case position <= pointsPositions:
 			posColor = ResultsGreen // Green for points
-		case isDisqualified:
-			posColor = ResultsRed // Red for DSQ
 		default:
 			posColor = ResultsReset // Normal for no points
 		}
 
+		positionText := fmt.Sprintf("%d", position)
+		if isDisqualified {
+			positionText = "DSQ"
+		}
+
+		// The original finishing position, highlighted when the stewards changed it
+		origColor := ResultsReset
+		if scored.Adjusted || isDisqualified {
+			origColor = ResultsCyan
+		}
+
+		// Gap to the winner, or why the driver didn't finish
+		gapText, gapColor := result.Gap, ResultsReset
+		switch {
+		case result.DNS:
+			gapText, gapColor = "DNS", ResultsRed
+		case result.DNF:
+			gapText, gapColor = "DNF", ResultsRed
+			if result.Laps > 0 {
+				gapText = fmt.Sprintf("DNF (L%d)", result.Laps)
+			}
+		}
+
 		// Team colors
 		teamColor := getResultsTeamColor(teamName)
 
-		fmt.Printf("%s%-3d%s %-25s %s%-20s%s %s#%-6d%s",
-			posColor, result.Position, ResultsReset,
+		fmt.Printf("%s%-3s%s %s%-4d%s %-25s %s%-20s%s %s#%-6d%s %s%-11s%s",
+			posColor, positionText, ResultsReset,
+			origColor, result.Position, ResultsReset,
 			truncateString(driverName, 25),
 			teamColor, truncateString(teamName, 20), ResultsReset,
-			ResultsCyan, result.DriverNumber, ResultsReset)
+			ResultsCyan, result.DriverNumber, ResultsReset,
+			gapColor, truncateString(gapText, 11), ResultsReset)
 
 		if isDisqualified {
 			fmt.Printf(" %s(DSQ)%s", ResultsRed+ResultsBold, ResultsReset)
@@ -196,40 +269,62 @@ func Results(args []string, dataService *data.DataService) {
 			} else {
 				pointsColor = ResultsGreen
 			}
-			fmt.Printf(" %s(%d pts)%s", pointsColor, points, ResultsReset)
+			fmt.Printf(" %s(%s pts)%s", pointsColor, data.FormatPoints(points), ResultsReset)
+		}
+		if scored.FastestLapBonus || (fastest != nil && fastest.DriverNumber == result.DriverNumber) {
+			fmt.Printf(" %s(FL)%s", ResultsMagenta, ResultsReset)
+		}
+		if tyres := wetTyres[result.DriverNumber]; len(tyres) > 0 {
+			fmt.Printf(" %s🌧 %s%s", ResultsBlue, strings.Join(tyres, "/"), ResultsReset)
 		}
 		fmt.Println()
 
 		// Add visual separators
 		if i == 2 { // After podium
 			fmt.Printf("%s%s%s\n", ResultsCyan, strings.Repeat("┄", 80), ResultsReset)
-		} else if result.Position == 10 && sessionType == "Race" { // After points in race
-			fmt.Printf("%s%s%s\n", ResultsMagenta, strings.Repeat("┄", 80), ResultsReset)
-		} else if result.Position == 8 && sessionType == "Sprint" { // After points in sprint
+		} else if position == pointsPositions && !isDisqualified { // After the points-paying positions
 			fmt.Printf("%s%s%s\n", ResultsMagenta, strings.Repeat("┄", 80), ResultsReset)
 		}
 	}
 
-	fmt.Printf("\n%sTotal finishers: %d%s\n", ResultsBold+ResultsCyan, len(results), ResultsReset)
+	finishers := 0
+	for _, result := range results {
+		if !result.DNF && !result.DNS && !result.DSQ {
+			finishers++
+		}
+	}
+	fmt.Printf("\n%sTotal finishers: %d%s\n", ResultsBold+ResultsCyan, finishers, ResultsReset)
+	if fastest != nil {
+		// The lap is shared with the data service, so name a copy
+		lap := *fastest
+		if name := driverNames[lap.DriverNumber]; lap.Driver == "" && name != "" {
+			lap.Driver = name
+		}
+		fmt.Printf("%sFastest lap:%s %s\n", ResultsMagenta, ResultsReset, lap)
+	}
+	scale := data.RegulationsFor(targetSession.Season()).PointsScale(*targetSession)
 	if sessionType == "Race" {
-		fmt.Printf("%sPoints:%s 25-18-15-12-10-8-6-4-2-1 (positions 1-10)\n", ResultsGreen, ResultsReset)
+		fmt.Printf("%sPoints:%s %s (positions 1-%d)\n", ResultsGreen, ResultsReset,
+			data.FormatPointsScale(scale), len(scale))
+	} else if len(scale) > 0 {
+		fmt.Printf("%sSprint Points:%s %s (positions 1-%d)\n", ResultsYellow, ResultsReset,
+			data.FormatPointsScale(scale), len(scale))
 	} else {
-		fmt.Printf("%sSprint Points:%s 8-7-6-5-4-3-2-1 (positions 1-8)\n", ResultsYellow, ResultsReset)
+		fmt.Printf("%sSprint Points:%s none awarded in %d\n", ResultsYellow, ResultsReset, targetSession.Season())
 	}
-}
 
-// Helper function to check if a string contains a substring (case-insensitive)
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		(s == substr ||
-			s[:len(substr)] == substr ||
-			s[len(s)-len(substr):] == substr ||
-			findInString(s, substr))
+	if corrections := dataService.Penalties().ForSession(*targetSession); len(corrections) > 0 {
+		fmt.Printf("\n%sStewards' decisions:%s\n", ResultsBold+ResultsRed, ResultsReset)
+		for _, correction := range corrections {
+			fmt.Printf("  #%-3d %s - %s\n", correction.DriverNumber, describeCorrection(correction), correction.Reason)
+		}
+	}
 }
 
-func findInString(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
+// containsString reports whether values includes s
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
 			return true
 		}
 	}
@@ -277,10 +372,13 @@ func ShowResultsHelp() {
 	fmt.Printf("%s%s%s\n", ResultsBold, strings.Repeat("═", 50), ResultsReset)
 	fmt.Println()
 	fmt.Printf("%sUsage:%s\n", ResultsBold+ResultsGreen, ResultsReset)
-	fmt.Printf("  %sf1 results <location> [session_type]%s\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 results <location|last|next> [session_type]%s\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 results --round <n> [session_type]%s\n", ResultsCyan, ResultsReset)
 	fmt.Println()
 	fmt.Printf("%sArguments:%s\n", ResultsBold+ResultsGreen, ResultsReset)
-	fmt.Printf("  %slocation%s       Circuit location (e.g., Shanghai, Monaco, Silverstone)\n", ResultsYellow, ResultsReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name (e.g., Shanghai, British)\n", ResultsYellow, ResultsReset)
+	fmt.Printf("  %slast, next%s     The most recent finished session, or the next one to run\n", ResultsYellow, ResultsReset)
+	fmt.Printf("  %s--round <n>%s    Championship round (pre-season testing is not a round)\n", ResultsYellow, ResultsReset)
 	fmt.Printf("  %ssession_type%s   'race' (default) or 'sprint'\n", ResultsYellow, ResultsReset)
 	fmt.Println()
 	fmt.Printf("%sExamples:%s\n", ResultsBold+ResultsGreen, ResultsReset)
@@ -288,9 +386,16 @@ func ShowResultsHelp() {
 	fmt.Printf("  %sf1 results Shanghai sprint%s    # Show Shanghai sprint results\n", ResultsCyan, ResultsReset)
 	fmt.Printf("  %sf1 results Monaco%s             # Show Monaco race results\n", ResultsCyan, ResultsReset)
 	fmt.Printf("  %sf1 results Miami sprint%s       # Show Miami sprint results\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 results last%s               # Show the most recent race\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 results --round 5%s          # Show round 5 of the championship\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 --season 2024 results Monaco%s # Show Monaco results from 2024\n", ResultsCyan, ResultsReset)
+	fmt.Printf("  %sf1 results Monaco --season 2008%s # Historical results via the Jolpica API\n", ResultsCyan, ResultsReset)
 	fmt.Println()
-	fmt.Printf("%sNote:%s Available locations include Shanghai, Melbourne, Miami, Monaco, etc.\n",
+	fmt.Printf("%sNote:%s Names ignore case and accents; if one fits several events the candidates\n",
 		ResultsBold+ResultsMagenta, ResultsReset)
+	fmt.Printf("      are listed with their round numbers\n")
 	fmt.Printf("      %sPoints are shown with DSQ (disqualification) indicators%s\n",
 		ResultsGreen, ResultsReset)
+	fmt.Printf("      %sIn wet races, 🌧 marks who ran on intermediates (I) or wets (W)%s\n",
+		ResultsBlue, ResultsReset)
 }
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f1cli/data"
+)
+
+// selectSession picks the session a user asked for from candidates, which
+// are all of one kind ("Race", "Qualifying"): by championship round, "last",
+// "next", or by place name. When nothing fits, or a name fits more than one
+// event, it says so and returns nil.
+func selectSession(candidates []data.Session, query string, round int, kind string, season int) *data.Session {
+	var target *data.Session
+	switch {
+	case round > 0:
+		for i := range candidates {
+			if candidates[i].Round == round {
+				target = &candidates[i]
+			}
+		}
+	case strings.EqualFold(query, "last"):
+		// Sessions are in calendar order, so keep the latest that has ended
+		for i := range candidates {
+			if candidates[i].HasEnded() {
+				target = &candidates[i]
+			}
+		}
+	case strings.EqualFold(query, "next"):
+		for i := range candidates {
+			if !candidates[i].HasEnded() {
+				target = &candidates[i]
+				break
+			}
+		}
+	default:
+		// Anything else is a place: rank sessions by how well their location,
+		// country, circuit or meeting name matches
+		best, tied, ok := data.BestSessionMatch(data.MatchSessions(candidates, query))
+		if ok {
+			target = &best.Session
+		} else if len(tied) > 0 {
+			fmt.Printf("'%s' matches more than one %d %s:\n", query, season, kind)
+			for _, match := range tied {
+				label := "  -"
+				if match.Session.Round > 0 {
+					label = fmt.Sprintf("%2d.", match.Session.Round)
+				}
+				fmt.Printf("  %s %-15s %s (%s)\n", label, match.Session.Location,
+					match.Session.MeetingName, match.Session.Country)
+			}
+			fmt.Println("\nBe more specific, or use --round <n>")
+			return nil
+		}
+	}
+
+	if target != nil {
+		return target
+	}
+
+	switch {
+	case round > 0:
+		fmt.Printf("No %d %s session found for round %d\n", season, kind, round)
+	case strings.EqualFold(query, "last"):
+		fmt.Printf("No %d %s has finished yet\n", season, kind)
+		return nil
+	case strings.EqualFold(query, "next"):
+		fmt.Printf("No %d %s left to run\n", season, kind)
+		return nil
+	default:
+		fmt.Printf("No %d %s session found for location: %s\n", season, kind, query)
+	}
+	fmt.Println("\nAvailable locations:")
+	seen := make(map[string]bool)
+	for _, session := range candidates {
+		if !seen[session.Location] {
+			if session.Round > 0 {
+				fmt.Printf("  %2d. %s\n", session.Round, session.Location)
+			} else {
+				fmt.Printf("  - %s\n", session.Location)
+			}
+			seen[session.Location] = true
+		}
+	}
+	return nil
+}
+
+// sessionName reads a session argument: race, sprint, qualifying or fp1-fp3
+func sessionName(arg string) (string, bool) {
+	switch {
+	case strings.EqualFold(arg, "race"):
+		return "Race", true
+	case strings.EqualFold(arg, "sprint"):
+		return "Sprint", true
+	case strings.EqualFold(arg, "qualifying"):
+		return "Qualifying", true
+	case strings.HasPrefix(strings.ToLower(arg), "fp") && practiceNumber(arg) > 0:
+		return fmt.Sprintf("Practice %d", practiceNumber(arg)), true
+	}
+	return "", false
+}
+
+// sessionsNamed returns the season's sessions with the given name, such as
+// "Race" or "Practice 2", in calendar order
+func sessionsNamed(ctx context.Context, dataService *data.DataService, name string) ([]data.Session, error) {
+	var sessions []data.Session
+	var err error
+	switch {
+	case name == "Qualifying":
+		sessions, err = dataService.GetWeekendSessionsContext(ctx, "Qualifying")
+	case strings.HasPrefix(name, "Practice"):
+		sessions, err = dataService.GetWeekendSessionsContext(ctx, "Practice")
+	default:
+		sessions, err = dataService.GetSessionsContext(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var named []data.Session
+	for _, session := range sessions {
+		if session.Name == name {
+			named = append(named, session)
+		}
+	}
+	return named, nil
+}
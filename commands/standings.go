@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"strings"
@@ -25,7 +26,7 @@ const (
 )
 
 // Standings shows driver or constructor championship standings
-func Standings(args []string, dataService *data.DataService) {
+func Standings(ctx context.Context, args []string, dataService *data.DataService) {
 	fs := flag.NewFlagSet("standings", flag.ExitOnError)
 
 	constructor := fs.Bool("constructor", false, "Show constructor standings")
@@ -45,18 +46,18 @@ func Standings(args []string, dataService *data.DataService) {
 	showVerbose := *verbose || *verboseShort
 
 	if showConstructor {
-		showConstructorStandings(dataService)
+		showConstructorStandings(ctx, dataService, showVerbose)
 	} else {
-		showDriverStandings(dataService, showVerbose)
+		showDriverStandings(ctx, dataService, showVerbose)
 	}
 }
 
-func showDriverStandings(dataService *data.DataService, verbose bool) {
-	fmt.Printf("F1 2025 Driver Championship (%s)\n",
-		dataService.GetSourceName())
+func showDriverStandings(ctx context.Context, dataService *data.DataService, verbose bool) {
+	fmt.Printf("F1 %d Driver Championship (%s)\n",
+		dataService.Season(), dataService.GetSourceName())
 	fmt.Printf("%s%s%s\n", Bold, strings.Repeat("═", 80), Reset)
 
-	standings, err := dataService.GetDriverStandings()
+	standings, err := dataService.GetDriverStandingsContext(ctx)
 	if err != nil {
 		fmt.Printf("%s❌ Error fetching driver standings: %v%s\n", Red, err, Reset)
 		return
@@ -98,11 +99,11 @@ func showDriverStandings(dataService *data.DataService, verbose bool) {
 			pointsColor = Green
 		}
 
-		fmt.Printf("%s%-3d%s %-25s %s%-20s%s %s%6d%s %4d %s\n",
-			posColor, standing.Position, Reset,
+		fmt.Printf("%s%-3s%s %-25s %s%-20s%s %s%6s%s %4d %s\n",
+			posColor, standing.PositionLabel(), Reset,
 			standing.Driver,
 			teamColor, standing.Team, Reset,
-			pointsColor, standing.Points, Reset,
+			pointsColor, data.FormatPoints(standing.Points), Reset,
 			standing.Wins,
 			standing.Gap)
 
@@ -119,19 +120,89 @@ func showDriverStandings(dataService *data.DataService, verbose bool) {
 	// Championship leader info
 	if len(standings) > 1 {
 		leader := standings[0]
-		fmt.Printf("\nChampionship Leader: %s%s %s(%d points, %d wins)%s",
-			leader.Driver, Reset, Green, leader.Points, leader.Wins, Reset)
+		fmt.Printf("\nChampionship Leader: %s%s %s(%s points, %d wins)%s",
+			leader.Driver, Reset, Green, data.FormatPoints(leader.Points), leader.Wins, Reset)
 	}
 	fmt.Println()
 
+	// Session states only matter for standings computed from results
+	if sessions, err := dataService.GetSessionsContext(ctx); err == nil {
+		showSessionStateNotes(sessions)
+	}
+
 	if verbose {
-		fmt.Printf("\nPoints System Information:%s\n", Reset)
-		fmt.Printf("   Race Points: 25-18-15-12-10-8-6-4-2-1 (positions 1-10)\n")
-		fmt.Printf("   Sprint Points: 8-7-6-5-4-3-2-1 (positions 1-8)\n")
+		fmt.Printf("\nPoints System Information (%d):%s\n", dataService.Season(), Reset)
+		for _, line := range describeRegulations(dataService.Season()) {
+			fmt.Printf("   %s\n", line)
+		}
 		fmt.Printf("   Wins count: Only main races (not sprints)\n")
+		fmt.Printf("   Ties: broken on most wins, then 2nd places, and so on\n")
+		showTieBreaks(standings)
+	}
+}
+
+// showSessionStateNotes flags sessions whose results are not final: live
+// sessions are left out until they end, provisional ones may still change
+func showSessionStateNotes(sessions []data.Session) {
+	for _, session := range sessions {
+		switch session.State() {
+		case data.StateLive:
+			fmt.Printf("%s🔴 LIVE:%s %s %s is in progress and not counted yet\n",
+				Bold+Red, Reset, session.Location, session.Name)
+		case data.StateProvisional:
+			fmt.Printf("%s⏳ Provisional:%s %s %s results may still change\n",
+				Bold+Yellow, Reset, session.Location, session.Name)
+		}
+	}
+}
+
+// showTieBreaks explains how entries on equal points were ordered
+func showTieBreaks(standings []data.StandingEntry) {
+	var lines []string
+	for _, standing := range standings {
+		if standing.TieBreak != "" {
+			lines = append(lines, fmt.Sprintf("%s %s: %s", standing.PositionLabel(), standing.Driver, standing.TieBreak))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%sTie-breaks:%s\n", Bold+Blue, Reset)
+	for _, line := range lines {
+		fmt.Printf("   %s\n", line)
 	}
 }
 
+// describeRegulations summarises a season's points system, one rule per line
+func describeRegulations(season int) []string {
+	regs := data.RegulationsFor(season)
+
+	lines := []string{fmt.Sprintf("Race Points: %s (positions 1-%d)",
+		data.FormatPointsScale(regs.RacePoints), len(regs.RacePoints))}
+
+	if len(regs.SprintPoints) > 0 {
+		lines = append(lines, fmt.Sprintf("Sprint Points: %s (positions 1-%d)",
+			data.FormatPointsScale(regs.SprintPoints), len(regs.SprintPoints)))
+	}
+
+	if regs.FastestLapPoints > 0 {
+		rule := fmt.Sprintf("Fastest Lap: +%s", data.FormatPoints(regs.FastestLapPoints))
+		if regs.FastestLapTopN > 0 {
+			rule += fmt.Sprintf(" (top %d finishers only)", regs.FastestLapTopN)
+		}
+		lines = append(lines, rule)
+	}
+
+	if len(regs.ShortRaceScales) > 0 {
+		lines = append(lines, "Shortened races: reduced points scales below 75% distance")
+	} else if regs.HalfPointsBelow > 0 {
+		lines = append(lines, fmt.Sprintf("Shortened races: half points below %.0f%% distance", regs.HalfPointsBelow*100))
+	}
+
+	return lines
+}
+
 // getTeamColor returns ANSI color codes for different F1 teams
 func getTeamColor(team string) string {
 	switch team {
@@ -160,12 +231,12 @@ func getTeamColor(team string) string {
 	}
 }
 
-func showConstructorStandings(dataService *data.DataService) {
-	fmt.Printf("F1 2025 Constructor Championship (%s)\n",
-		dataService.GetSourceName())
+func showConstructorStandings(ctx context.Context, dataService *data.DataService, verbose bool) {
+	fmt.Printf("F1 %d Constructor Championship (%s)\n",
+		dataService.Season(), dataService.GetSourceName())
 	fmt.Printf("%s%s%s\n", Bold, strings.Repeat("═", 80), Reset)
 
-	standings, err := dataService.GetConstructorStandings()
+	standings, err := dataService.GetConstructorStandingsContext(ctx)
 	if err != nil {
 		fmt.Printf("%s❌ Error fetching constructor standings: %v%s\n", Red, err, Reset)
 		return
@@ -205,11 +276,11 @@ func showConstructorStandings(dataService *data.DataService) {
 			pointsColor = Green
 		}
 
-		fmt.Printf("%s%-3d%s %s%-25s%s %-15s %s%6d%s %4d %s\n",
-			posColor, standing.Position, Reset,
+		fmt.Printf("%s%-3s%s %s%-25s%s %-15s %s%6s%s %4d %s\n",
+			posColor, standing.PositionLabel(), Reset,
 			teamColor, standing.Driver, Reset, // Constructor name
 			standing.Team, // Country
-			pointsColor, standing.Points, Reset,
+			pointsColor, data.FormatPoints(standing.Points), Reset,
 			standing.Wins,
 			standing.Gap)
 
@@ -224,10 +295,18 @@ func showConstructorStandings(dataService *data.DataService) {
 	// Championship leader info
 	if len(standings) > 1 {
 		leader := standings[0]
-		fmt.Printf("\nConstructor Champion: %s%s %s(%d points, %d wins)%s",
-			leader.Driver, Reset, Green, leader.Points, leader.Wins, Reset)
+		fmt.Printf("\nConstructor Champion: %s%s %s(%s points, %d wins)%s",
+			leader.Driver, Reset, Green, data.FormatPoints(leader.Points), leader.Wins, Reset)
 	}
 	fmt.Println()
+
+	if sessions, err := dataService.GetSessionsContext(ctx); err == nil {
+		showSessionStateNotes(sessions)
+	}
+
+	if verbose {
+		showTieBreaks(standings)
+	}
 }
 
 func ShowStandingsHelp() {
@@ -239,7 +318,7 @@ func ShowStandingsHelp() {
 	fmt.Println()
 	fmt.Printf("%sFlags:%s\n", Bold+Green, Reset)
 	fmt.Printf("  %s-c, -constructor%s   Show constructor standings instead of driver standings\n", Yellow, Reset)
-	fmt.Printf("  %s-v, -verbose%s       Show detailed points system information\n", Yellow, Reset)
+	fmt.Printf("  %s-v, -verbose%s       Show points system information and how ties were broken\n", Yellow, Reset)
 	fmt.Printf("  %s-help%s              Show help for standings command\n", Yellow, Reset)
 	fmt.Println()
 	fmt.Printf("%sExamples:%s\n", Bold+Green, Reset)
@@ -247,11 +326,15 @@ func ShowStandingsHelp() {
 	fmt.Printf("  %sf1 standings -c%s               # Show constructor championship standings\n", Cyan, Reset)
 	fmt.Printf("  %sf1 standings -v%s               # Show driver standings with points system info\n", Cyan, Reset)
 	fmt.Printf("  %sf1 standings -constructor%s     # Show constructor championship standings\n", Cyan, Reset)
+	fmt.Printf("  %sf1 --season 2024 standings%s    # Show the 2024 driver championship\n", Cyan, Reset)
 	fmt.Println()
 	fmt.Printf("%sPoints Systems:%s\n", Bold+Blue, Reset)
 	fmt.Printf("  Race: 25-18-15-12-10-8-6-4-2-1 points (positions 1-10)\n")
 	fmt.Printf("  Sprint: 8-7-6-5-4-3-2-1 points (positions 1-8)\n")
+	fmt.Printf("  Earlier seasons use the rules of their time (see -v)\n")
+	fmt.Printf("  Equal points are split on countback; \"=7\" marks a shared place\n")
 	fmt.Println()
-	fmt.Printf("%sNote:%s Standings are calculated from real race results using %sOpenF1 API%s\n",
+	fmt.Printf("%sNote:%s Standings are calculated from real race results from the %s--source%s backend\n",
 		Bold+Magenta, Reset, Bold+Cyan, Reset)
+	fmt.Printf("      (default: %s; 'f1 status' shows which one is in use)\n", data.DefaultSourceName)
 }
@@ -1,24 +1,27 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"f1cli/data"
 )
 
 // Status shows the status of data sources
-func Status(args []string, dataService *data.DataService) {
+func Status(ctx context.Context, args []string, dataService *data.DataService) {
 	fmt.Println("F1 CLI Data Source Status")
 	fmt.Println("══════════════════════════════════════════════")
 
 	fmt.Printf("Current Source: %s\n", dataService.GetSourceName())
+	fmt.Printf("Available Sources: %s (choose with --source)\n", strings.Join(data.SourceNames(), ", "))
 
 	fmt.Print("API Connectivity: ")
-	if dataService.IsOnline() {
+	if dataService.IsOnlineContext(ctx) {
 		fmt.Println("✅ Online")
 	} else {
 		fmt.Println("❌ Offline or unreachable")
-		fmt.Println("\nThe OpenF1 API might be:")
+		fmt.Printf("\nThe %s might be:\n", dataService.GetSourceName())
 		fmt.Println("   • Temporarily down")
 		fmt.Println("   • Blocked by firewall")
 		fmt.Println("   • Rate limited")
@@ -26,8 +29,14 @@ func Status(args []string, dataService *data.DataService) {
 	}
 
 	fmt.Println("\nData Source Information:")
-	fmt.Println("   • OpenF1 API (https://api.openf1.org/v1)")
-	fmt.Println("   • Real-time F1 data and telemetry")
+	if url := dataService.GetSourceURL(); url != "" {
+		fmt.Printf("   • %s (%s)\n", dataService.GetSourceName(), url)
+	} else {
+		fmt.Printf("   • %s\n", dataService.GetSourceName())
+	}
+	if _, ok := dataService.Source().(data.LapSource); ok {
+		fmt.Println("   • Lap times, tyres and weather")
+	}
 	fmt.Println("   • Session information and results")
 	fmt.Println("   • Driver and team information")
 }
@@ -0,0 +1,328 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for strategy command
+const (
+	StrategyReset   = "\033[0m"
+	StrategyBold    = "\033[1m"
+	StrategyRed     = "\033[31m"
+	StrategyGreen   = "\033[32m"
+	StrategyYellow  = "\033[33m"
+	StrategyBlue    = "\033[34m"
+	StrategyMagenta = "\033[35m"
+	StrategyCyan    = "\033[36m"
+	StrategyWhite   = "\033[37m"
+)
+
+// strategyBarWidth is how many characters a whole race distance is drawn across
+const strategyBarWidth = 50
+
+// Strategy draws every driver's race as a bar of tyre stints with their pit
+// stops, followed by a pit stop leaderboard by team
+func Strategy(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowStrategyHelp()
+		return
+	}
+
+	location := ""
+	sessionType := "Race"
+	round := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowStrategyHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		case strings.EqualFold(arg, "sprint"):
+			sessionType = "Sprint"
+		case strings.EqualFold(arg, "race"):
+			sessionType = "Race"
+		case location == "":
+			location = arg
+		}
+	}
+
+	if location == "" && round == 0 {
+		ShowStrategyHelp()
+		return
+	}
+
+	sessions, err := dataService.GetSessionsContext(ctx)
+	if err != nil {
+		fmt.Printf("Error getting sessions: %v\n", err)
+		return
+	}
+
+	var candidates []data.Session
+	for _, session := range sessions {
+		if session.Name == sessionType {
+			candidates = append(candidates, session)
+		}
+	}
+	targetSession := selectSession(candidates, location, round, sessionType, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	state := targetSession.State()
+	if state == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, sessionType,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	stints, err := dataService.GetStintsContext(ctx, *targetSession, 0)
+	if err != nil {
+		fmt.Printf("Error getting tyre stints for %s %s: %v\n", targetSession.Location, sessionType, err)
+		return
+	}
+	if len(stints) == 0 {
+		fmt.Printf("No tyre data for %s %s yet\n", targetSession.Location, sessionType)
+		return
+	}
+
+	// Stop times, the finishing order and names fill in the picture, but the
+	// stints alone are enough to draw it
+	stops, stopsErr := dataService.GetPitStopsContext(ctx, *targetSession)
+	results, err := dataService.GetClassificationContext(ctx, *targetSession)
+	if err != nil {
+		results = nil
+	}
+	codes := make(map[int]string)
+	teams := make(map[int]string)
+	if entrants, err := dataService.GetEntrantsContext(ctx, *targetSession); err == nil {
+		for _, driver := range entrants {
+			codes[driver.Number] = driver.Code()
+			teams[driver.Number] = driver.Team
+		}
+	}
+
+	stintsByDriver := make(map[int][]data.Stint)
+	lastLap := make(map[int]int)
+	raceLaps := 0
+	for _, stint := range stints {
+		stintsByDriver[stint.DriverNumber] = append(stintsByDriver[stint.DriverNumber], stint)
+		lastLap[stint.DriverNumber] = max(lastLap[stint.DriverNumber], stint.LapEnd)
+		raceLaps = max(raceLaps, stint.LapEnd)
+	}
+	stopsByDriver := make(map[int][]data.PitStop)
+	for _, stop := range stops {
+		stopsByDriver[stop.DriverNumber] = append(stopsByDriver[stop.DriverNumber], stop)
+	}
+
+	// Finishing order first, then anyone the classification doesn't cover
+	var order []int
+	positions := make(map[int]string)
+	listed := make(map[int]bool)
+	for _, result := range results {
+		if _, ok := stintsByDriver[result.DriverNumber]; !ok {
+			continue
+		}
+		order = append(order, result.DriverNumber)
+		listed[result.DriverNumber] = true
+		switch {
+		case result.DSQ:
+			positions[result.DriverNumber] = "DSQ"
+		case result.DNF || result.DNS:
+			positions[result.DriverNumber] = "DNF"
+		case result.Position > 0:
+			positions[result.DriverNumber] = strconv.Itoa(result.Position)
+		}
+		if result.Laps > 0 {
+			lastLap[result.DriverNumber] = result.Laps
+		}
+	}
+	var unclassified []int
+	for number := range stintsByDriver {
+		if !listed[number] {
+			unclassified = append(unclassified, number)
+		}
+	}
+	sort.Ints(unclassified)
+	order = append(order, unclassified...)
+
+	stationary := data.HasStationaryTimes(stops)
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s Strategy%s - %s%s%s\n",
+		StrategyBold+StrategyYellow, roundLabel, targetSession.Location, sessionType, StrategyReset,
+		StrategyCyan, targetSession.DateStart.Format("2006-01-02"), StrategyReset)
+	fmt.Printf("%s%s%s\n", StrategyBold, strings.Repeat("═", 90), StrategyReset)
+	if state == data.StateLive {
+		fmt.Printf("%s🔴 LIVE - strategies so far%s\n", StrategyBold+StrategyRed, StrategyReset)
+	}
+
+	fmt.Printf("%s%-4s %-4s %-*s %s%s\n", StrategyBold+StrategyWhite, "POS", "DRV",
+		strategyBarWidth, fmt.Sprintf("LAPS 1-%d", raceLaps), "STOPS", StrategyReset)
+	fmt.Printf("%s%s%s\n", StrategyBold, strings.Repeat("─", 90), StrategyReset)
+
+	for _, number := range order {
+		code := codes[number]
+		if code == "" {
+			code = fmt.Sprintf("#%d", number)
+		}
+		fmt.Printf("%-4s %s%-4s%s %s", positions[number], getStrategyTeamColor(teams[number]), code, StrategyReset,
+			strategyBar(stintsByDriver[number], lastLap[number], raceLaps))
+
+		var stopTexts []string
+		for _, stop := range stopsByDriver[number] {
+			text := fmt.Sprintf("L%d", stop.Lap)
+			if t := stop.Duration(stationary); t > 0 {
+				text += fmt.Sprintf(" %.1fs", t)
+			}
+			stopTexts = append(stopTexts, text)
+		}
+		fmt.Printf(" %s\n", strings.Join(stopTexts, ", "))
+	}
+
+	fmt.Printf("\n%sS%s soft  %sM%s medium  %sH%s hard  %sI%s inter  %sW%s wet  │ pit stop\n",
+		getCompoundColor("SOFT"), StrategyReset, getCompoundColor("MEDIUM"), StrategyReset,
+		getCompoundColor("HARD"), StrategyReset, getCompoundColor("INTERMEDIATE"), StrategyReset,
+		getCompoundColor("WET"), StrategyReset)
+
+	if stopsErr != nil {
+		fmt.Printf("%sNote:%s pit stop times unavailable (%v)\n", StrategyMagenta, StrategyReset, stopsErr)
+		return
+	}
+
+	leaderboard := data.PitStopLeaderboard(stops, teams)
+	if len(leaderboard) == 0 {
+		return
+	}
+	timeKind := "pit lane time, entry to exit"
+	if stationary {
+		timeKind = "stationary time"
+	}
+	fmt.Printf("\n%sPit stops by team%s (%s)\n", StrategyBold+StrategyYellow, StrategyReset, timeKind)
+	fmt.Printf("%s%s%s\n", StrategyBold, strings.Repeat("─", 90), StrategyReset)
+	fmt.Printf("%s%-3s %-20s %-6s %-9s %-22s %-8s%s\n", StrategyBold+StrategyWhite,
+		"#", "TEAM", "STOPS", "FASTEST", "BY", "MEDIAN", StrategyReset)
+	for i, entry := range leaderboard {
+		posColor := StrategyReset
+		if i == 0 {
+			posColor = StrategyBold + StrategyYellow
+		}
+		by := codes[entry.Fastest.DriverNumber]
+		if by == "" {
+			by = fmt.Sprintf("#%d", entry.Fastest.DriverNumber)
+		}
+		fmt.Printf("%s%-3d%s %s%-20s%s %-6d %-9s %-22s %.2fs\n",
+			posColor, i+1, StrategyReset,
+			getStrategyTeamColor(entry.Team), truncateString(entry.Team, 20), StrategyReset,
+			entry.Stops, fmt.Sprintf("%.2fs", entry.Fastest.Duration(stationary)),
+			fmt.Sprintf("%s, lap %d", by, entry.Fastest.Lap), entry.Median)
+	}
+	if !stationary {
+		fmt.Println("\nPit lane times include the drive through the lane; penalties and red flags inflate them")
+	}
+}
+
+// strategyBar draws a driver's stints scaled to the race distance, with a
+// marker where each new set of tyres went on. Laps after lastLap are blank.
+func strategyBar(stints []data.Stint, lastLap, raceLaps int) string {
+	if raceLaps == 0 {
+		return strings.Repeat(" ", strategyBarWidth)
+	}
+	lapAt := func(column int) int { return column*raceLaps/strategyBarWidth + 1 }
+
+	var bar strings.Builder
+	for column := 0; column < strategyBarWidth; column++ {
+		lap := lapAt(column)
+		if lastLap > 0 && lap > lastLap {
+			bar.WriteString(" ")
+			continue
+		}
+
+		stint, ok := data.StintForLap(stints, data.Lap{DriverNumber: stints[0].DriverNumber, Number: lap})
+		switch {
+		case !ok:
+			bar.WriteString(" ")
+		case stint.Number > 1 && lap >= stint.LapStart && (column == 0 || lapAt(column-1) < stint.LapStart):
+			bar.WriteString(StrategyBold + StrategyWhite + "│" + StrategyReset)
+		case stint.Compound == "" || strings.EqualFold(stint.Compound, "UNKNOWN"):
+			bar.WriteString("░")
+		default:
+			bar.WriteString(getCompoundColor(stint.Compound) + "█" + StrategyReset)
+		}
+	}
+	return bar.String()
+}
+
+// getStrategyTeamColor returns ANSI color codes for different F1 teams
+func getStrategyTeamColor(team string) string {
+	switch team {
+	case "McLaren":
+		return "\033[38;5;208m" // Orange
+	case "Red Bull Racing":
+		return "\033[38;5;27m" // Blue
+	case "Ferrari":
+		return StrategyRed
+	case "Mercedes":
+		return "\033[38;5;51m" // Cyan
+	case "Aston Martin":
+		return StrategyGreen
+	case "Alpine":
+		return "\033[38;5;129m" // Pink
+	case "Williams":
+		return StrategyBlue
+	case "Haas F1 Team", "Haas":
+		return "\033[38;5;245m" // Gray
+	case "Kick Sauber":
+		return "\033[38;5;46m" // Bright Green
+	case "Racing Bulls":
+		return "\033[38;5;63m" // Purple
+	default:
+		return StrategyReset
+	}
+}
+
+func ShowStrategyHelp() {
+	fmt.Printf("%sF1 Strategy%s\n", StrategyBold+StrategyYellow, StrategyReset)
+	fmt.Printf("%s%s%s\n", StrategyBold, strings.Repeat("═", 50), StrategyReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", StrategyBold+StrategyGreen, StrategyReset)
+	fmt.Printf("  %sf1 strategy <location|last|next> [sprint]%s\n", StrategyCyan, StrategyReset)
+	fmt.Printf("  %sf1 strategy --round <n> [sprint]%s\n", StrategyCyan, StrategyReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", StrategyBold+StrategyGreen, StrategyReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", StrategyYellow, StrategyReset)
+	fmt.Printf("  %ssprint%s         The sprint instead of the Grand Prix\n", StrategyYellow, StrategyReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", StrategyBold+StrategyGreen, StrategyReset)
+	fmt.Printf("  %sf1 strategy Monza%s        # Tyres and stops in the Italian Grand Prix\n", StrategyCyan, StrategyReset)
+	fmt.Printf("  %sf1 strategy last%s         # The most recent race\n", StrategyCyan, StrategyReset)
+	fmt.Println()
+	fmt.Printf("%sNote:%s Stationary times are published from 2025; earlier seasons rank\n",
+		StrategyBold+StrategyMagenta, StrategyReset)
+	fmt.Println("      pit stops by their time from pit entry to pit exit")
+}
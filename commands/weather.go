@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"f1cli/data"
+)
+
+// ANSI color codes for weather command
+const (
+	WeatherReset   = "\033[0m"
+	WeatherBold    = "\033[1m"
+	WeatherRed     = "\033[31m"
+	WeatherGreen   = "\033[32m"
+	WeatherYellow  = "\033[33m"
+	WeatherBlue    = "\033[34m"
+	WeatherMagenta = "\033[35m"
+	WeatherCyan    = "\033[36m"
+	WeatherWhite   = "\033[37m"
+)
+
+// weatherChartWidth is how many columns the whole session is drawn across
+const weatherChartWidth = 60
+
+// sparkLevels draw a value from its row's lowest to its highest
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Weather charts the temperatures, humidity, wind and rain through a session
+func Weather(ctx context.Context, args []string, dataService *data.DataService) {
+	if len(args) == 0 {
+		ShowWeatherHelp()
+		return
+	}
+
+	location := ""
+	kind := "Race"
+	round := 0
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case arg == "-help" || arg == "--help":
+			ShowWeatherHelp()
+			return
+		case strings.HasPrefix(arg, "-") && name == "round":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fmt.Println("❌ Flag --round needs a round number")
+					return
+				}
+				i++
+				value = args[i]
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Invalid round %q\n", value)
+				return
+			}
+			round = n
+		default:
+			if name, ok := sessionName(arg); ok {
+				kind = name
+			} else if location == "" {
+				location = arg
+			}
+		}
+	}
+
+	if location == "" && round == 0 {
+		ShowWeatherHelp()
+		return
+	}
+
+	candidates, err := sessionsNamed(ctx, dataService, kind)
+	if err != nil {
+		fmt.Printf("Error getting sessions: %v\n", err)
+		return
+	}
+	targetSession := selectSession(candidates, location, round, kind, dataService.Season())
+	if targetSession == nil {
+		return
+	}
+
+	if targetSession.State() == data.StateUpcoming {
+		fmt.Printf("%s %s hasn't started yet (starts %s)\n", targetSession.Location, targetSession.Name,
+			targetSession.DateStart.Local().Format("Mon 2 Jan 2006 15:04"))
+		return
+	}
+
+	samples, err := dataService.GetWeatherContext(ctx, *targetSession)
+	if err != nil {
+		fmt.Printf("Error getting weather for %s %s: %v\n", targetSession.Location, targetSession.Name, err)
+		return
+	}
+	if len(samples) == 0 {
+		fmt.Printf("No weather readings for %s %s yet\n", targetSession.Location, targetSession.Name)
+		return
+	}
+
+	summary := data.SummariseWeather(samples)
+	chart := data.DownsampleWeather(samples, weatherChartWidth)
+
+	roundLabel := ""
+	if targetSession.Round > 0 {
+		roundLabel = fmt.Sprintf("Round %d: ", targetSession.Round)
+	}
+	fmt.Printf("%s%s%s %s Weather%s - %s%s%s\n",
+		WeatherBold+WeatherYellow, roundLabel, targetSession.Location, targetSession.Name, WeatherReset,
+		WeatherCyan, targetSession.DateStart.Format("2006-01-02"), WeatherReset)
+	fmt.Printf("%s%s%s\n", WeatherBold, strings.Repeat("═", 80), WeatherReset)
+	if targetSession.State() == data.StateLive {
+		fmt.Printf("%s🔴 LIVE - readings so far%s\n", WeatherBold+WeatherRed, WeatherReset)
+	}
+
+	rows := []struct {
+		label string
+		color string
+		unit  string
+		value func(data.WeatherSample) float64
+	}{
+		{"Air", WeatherYellow, "°C", func(s data.WeatherSample) float64 { return s.AirTemp }},
+		{"Track", WeatherRed, "°C", func(s data.WeatherSample) float64 { return s.TrackTemp }},
+		{"Humidity", WeatherCyan, "%", func(s data.WeatherSample) float64 { return s.Humidity }},
+		{"Wind", WeatherWhite, " m/s", func(s data.WeatherSample) float64 { return s.WindSpeed }},
+	}
+	for _, row := range rows {
+		values := make([]float64, len(chart))
+		for i, sample := range chart {
+			values[i] = row.value(sample)
+		}
+		low, high := minMax(values)
+		fmt.Printf("%s%-9s%s %s%s%s  %.1f-%.1f%s\n", WeatherBold, row.label, WeatherReset,
+			row.color, sparkline(values, low, high), WeatherReset, low, high, row.unit)
+	}
+
+	var rain strings.Builder
+	for _, sample := range chart {
+		if sample.Rain {
+			rain.WriteString(WeatherBlue + "█" + WeatherReset)
+		} else {
+			rain.WriteString("·")
+		}
+	}
+	fmt.Printf("%s%-9s%s %s\n", WeatherBold, "Rain", WeatherReset, rain.String())
+
+	start := samples[0].Time.Local().Format("15:04")
+	end := samples[len(samples)-1].Time.Local().Format("15:04")
+	fmt.Printf("%-9s %s%s%s\n", "", start, strings.Repeat(" ", max(1, len(chart)-len(start)-len(end))), end)
+
+	fmt.Println()
+	if summary.Wet() {
+		fmt.Printf("%s🌧  Rain%s in %.0f%% of readings, %s to %s\n", WeatherBold+WeatherBlue, WeatherReset,
+			summary.RainShare()*100, summary.FirstRain.Local().Format("15:04"), summary.LastRain.Local().Format("15:04"))
+	} else {
+		fmt.Printf("%s☀  Dry%s throughout\n", WeatherBold+WeatherYellow, WeatherReset)
+	}
+	prevailing := data.DownsampleWeather(samples, 1)[0]
+	fmt.Printf("%sWind:%s up to %.1f m/s, mostly from the %s\n", WeatherBold, WeatherReset,
+		summary.WindMax, compassPoint(prevailing.WindDirection))
+}
+
+// sparkline draws values as bars scaled between low and high
+func sparkline(values []float64, low, high float64) string {
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparkLevels)-1))
+		}
+		line.WriteRune(sparkLevels[level])
+	}
+	return line.String()
+}
+
+// minMax returns the smallest and largest of values, which must not be empty
+func minMax(values []float64) (float64, float64) {
+	low, high := values[0], values[0]
+	for _, value := range values[1:] {
+		low, high = min(low, value), max(high, value)
+	}
+	return low, high
+}
+
+// compassPoint names the direction a wind blows from, e.g. "NE"
+func compassPoint(degrees int) string {
+	points := []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+	return points[((degrees+22)%360)/45]
+}
+
+func ShowWeatherHelp() {
+	fmt.Printf("%sF1 Weather%s\n", WeatherBold+WeatherYellow, WeatherReset)
+	fmt.Printf("%s%s%s\n", WeatherBold, strings.Repeat("═", 50), WeatherReset)
+	fmt.Println()
+	fmt.Printf("%sUsage:%s\n", WeatherBold+WeatherGreen, WeatherReset)
+	fmt.Printf("  %sf1 weather <location|last|next> [session]%s\n", WeatherCyan, WeatherReset)
+	fmt.Printf("  %sf1 weather --round <n> [session]%s\n", WeatherCyan, WeatherReset)
+	fmt.Println()
+	fmt.Printf("%sArguments:%s\n", WeatherBold+WeatherGreen, WeatherReset)
+	fmt.Printf("  %slocation%s       Location, country, circuit or Grand Prix name\n", WeatherYellow, WeatherReset)
+	fmt.Printf("  %ssession%s        race (default), sprint, qualifying, fp1, fp2 or fp3\n", WeatherYellow, WeatherReset)
+	fmt.Println()
+	fmt.Printf("%sExamples:%s\n", WeatherBold+WeatherGreen, WeatherReset)
+	fmt.Printf("  %sf1 weather Interlagos%s         # Conditions through the São Paulo race\n", WeatherCyan, WeatherReset)
+	fmt.Printf("  %sf1 weather Spa qualifying%s     # Conditions through qualifying\n", WeatherCyan, WeatherReset)
+	fmt.Println()
+	fmt.Printf("%sNote:%s Each row is scaled between its own lowest and highest reading\n",
+		WeatherBold+WeatherMagenta, WeatherReset)
+}
@@ -1,27 +1,66 @@
 package data
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// FirstOpenF1Season is the earliest championship covered by the OpenF1 API
+const FirstOpenF1Season = 2023
+
+// CurrentSeason returns the championship year for today's date
+func CurrentSeason() int {
+	return time.Now().Year()
+}
+
+// finishedSessionGrace is how long after a session ends before its data is
+// treated as final and cached forever
+const finishedSessionGrace = 3 * time.Hour
+
 // APIClient connects to the OpenF1 API.
 // Note: OpenF1 provides raw race results - we calculate championship standings ourselves.
 type APIClient struct {
 	BaseURL string
 	Client  *http.Client
+	// Cache stores responses on disk; nil disables caching
+	Cache *ResponseCache
+	// Retry controls how transient failures are retried
+	Retry RetryPolicy
+	// Limiter throttles outgoing requests; nil disables client-side limiting
+	Limiter *RateLimiter
+	// CacheTTL overrides the OpenF1 cache policy for clients of other APIs
+	CacheTTL func(endpoint string) time.Duration
+
+	mu               sync.Mutex
+	finishedSessions map[int]bool
 }
 
 func NewAPIClient() *APIClient {
-	return &APIClient{
+	client := &APIClient{
 		BaseURL: "https://api.openf1.org/v1",
 		Client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		Retry:            DefaultRetryPolicy,
+		Limiter:          NewOpenF1RateLimiter(),
+		finishedSessions: make(map[int]bool),
 	}
+
+	if dir, err := DefaultCacheDir(); err == nil {
+		client.Cache = NewResponseCache(dir)
+	}
+
+	return client
 }
 
 // OpenF1 API response structures - field names must match API exactly for JSON unmarshaling
@@ -38,6 +77,64 @@ type OpenF1Driver struct {
 	CountryCode   string `json:"country_code"`
 }
 
+// OpenF1Stint is a run on one set of tyres, from the stints endpoint
+type OpenF1Stint struct {
+	DriverNumber   int    `json:"driver_number"`
+	StintNumber    int    `json:"stint_number"`
+	Compound       string `json:"compound"`
+	LapStart       int    `json:"lap_start"`
+	LapEnd         int    `json:"lap_end"`
+	TyreAgeAtStart int    `json:"tyre_age_at_start"`
+	SessionKey     int    `json:"session_key"`
+	MeetingKey     int    `json:"meeting_key"`
+}
+
+// OpenF1Weather is one reading from the weather endpoint, taken about once a minute
+type OpenF1Weather struct {
+	Date             time.Time `json:"date"`
+	AirTemperature   float64   `json:"air_temperature"`
+	TrackTemperature float64   `json:"track_temperature"`
+	Humidity         float64   `json:"humidity"`
+	Pressure         float64   `json:"pressure"`
+	// Rainfall is 1 while it is raining and 0 otherwise
+	Rainfall      float64 `json:"rainfall"`
+	WindSpeed     float64 `json:"wind_speed"`
+	WindDirection int     `json:"wind_direction"`
+	SessionKey    int     `json:"session_key"`
+	MeetingKey    int     `json:"meeting_key"`
+}
+
+// OpenF1Pit is a trip through the pit lane, from the pit endpoint. Times are
+// null when they weren't recorded; stop_duration only exists from 2025.
+type OpenF1Pit struct {
+	Date         time.Time `json:"date"`
+	DriverNumber int       `json:"driver_number"`
+	LapNumber    int       `json:"lap_number"`
+	// PitDuration is the time from pit entry to pit exit; LaneDuration replaces it
+	PitDuration  *float64 `json:"pit_duration"`
+	LaneDuration *float64 `json:"lane_duration"`
+	// StopDuration is the time stationary in the pit box
+	StopDuration *float64 `json:"stop_duration"`
+	SessionKey   int      `json:"session_key"`
+	MeetingKey   int      `json:"meeting_key"`
+}
+
+// OpenF1RaceControl is a message from race control: flags, safety cars and
+// stewards' notes
+type OpenF1RaceControl struct {
+	Date     time.Time `json:"date"`
+	Category string    `json:"category"`
+	Flag     string    `json:"flag"`
+	Scope    string    `json:"scope"`
+	// Sector and DriverNumber are null (zero) unless the scope needs them
+	Sector       int    `json:"sector"`
+	DriverNumber int    `json:"driver_number"`
+	LapNumber    int    `json:"lap_number"`
+	Message      string `json:"message"`
+	SessionKey   int    `json:"session_key"`
+	MeetingKey   int    `json:"meeting_key"`
+}
+
 type OpenF1Session struct {
 	SessionKey       int       `json:"session_key"`
 	SessionName      string    `json:"session_name"`
@@ -78,26 +175,187 @@ type OpenF1Position struct {
 	SessionKey   int       `json:"session_key"`
 }
 
+// OpenF1RaceResult is one line of the official classification from the
+// session_result endpoint
 type OpenF1RaceResult struct {
-	DriverNumber int    `json:"driver_number"`
-	Position     int    `json:"position"`
-	Points       int    `json:"points"`
-	Status       string `json:"status"`
-	SessionKey   int    `json:"session_key"`
-	MeetingKey   int    `json:"meeting_key"`
+	DriverNumber int     `json:"driver_number"`
+	Position     int     `json:"position"`
+	Points       float64 `json:"points"`
+	Status       string  `json:"status"`
+	NumberOfLaps int     `json:"number_of_laps"`
+	// GapToLeader is seconds behind the winner, or text such as "+1 LAP"
+	GapToLeader json.RawMessage `json:"gap_to_leader"`
+	// Duration is the race time in seconds, or for qualifying the best lap
+	// of each part (Q1, Q2, Q3) with null for parts the driver missed
+	Duration   json.RawMessage `json:"duration"`
+	DNF        bool            `json:"dnf"`
+	DNS        bool            `json:"dns"`
+	DSQ        bool            `json:"dsq"`
+	SessionKey int             `json:"session_key"`
+	MeetingKey int             `json:"meeting_key"`
+}
+
+// OpenF1Lap is one timed lap from the laps endpoint
+type OpenF1Lap struct {
+	DriverNumber int       `json:"driver_number"`
+	LapNumber    int       `json:"lap_number"`
+	DateStart    time.Time `json:"date_start"`
+	// LapDuration is null (zero) for laps that weren't timed, such as out laps
+	LapDuration     float64 `json:"lap_duration"`
+	DurationSector1 float64 `json:"duration_sector_1"`
+	DurationSector2 float64 `json:"duration_sector_2"`
+	DurationSector3 float64 `json:"duration_sector_3"`
+	IsPitOutLap     bool    `json:"is_pit_out_lap"`
+	I1Speed         int     `json:"i1_speed"`
+	I2Speed         int     `json:"i2_speed"`
+	SpeedTrapSpeed  int     `json:"st_speed"`
+	SessionKey      int     `json:"session_key"`
+	MeetingKey      int     `json:"meeting_key"`
+}
+
+// Gap returns the gap to the leader as text and, when it is a time, in seconds
+func (r OpenF1RaceResult) Gap() (string, float64) {
+	var seconds float64
+	if err := json.Unmarshal(r.GapToLeader, &seconds); err == nil {
+		if seconds == 0 {
+			return "", 0
+		}
+		return fmt.Sprintf("+%.3fs", seconds), seconds
+	}
+
+	var text string
+	if err := json.Unmarshal(r.GapToLeader, &text); err == nil {
+		return text, 0
+	}
+	return "", 0
+}
+
+// SegmentTimes returns a qualifying result's best lap in each part, in
+// seconds, with zero for parts the driver didn't set a time in
+func (r OpenF1RaceResult) SegmentTimes() [3]float64 {
+	var times [3]float64
+	var segments []*float64
+	if err := json.Unmarshal(r.Duration, &segments); err != nil {
+		return times
+	}
+	for i, segment := range segments {
+		if i < len(times) && segment != nil {
+			times[i] = *segment
+		}
+	}
+	return times
+}
+
+// ClassificationStatus describes how the driver's session ended
+func (r OpenF1RaceResult) ClassificationStatus() string {
+	switch {
+	case r.Status != "":
+		return r.Status
+	case r.DSQ:
+		return "Disqualified"
+	case r.DNS:
+		return "Did not start"
+	case r.DNF:
+		return "Retired"
+	default:
+		return "Finished"
+	}
 }
 
-func (c *APIClient) makeRequest(endpoint string) ([]byte, error) {
+func (c *APIClient) makeRequest(ctx context.Context, endpoint string) ([]byte, error) {
 	url := fmt.Sprintf("%s/%s", c.BaseURL, endpoint)
 
-	resp, err := c.Client.Get(url)
+	ttl := c.cacheTTL(endpoint)
+	if c.CacheTTL != nil {
+		ttl = c.CacheTTL(endpoint)
+	}
+	if c.Cache != nil {
+		if body, ok := c.Cache.Get(url, ttl); ok {
+			return body, nil
+		}
+	}
+
+	body, err := c.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Cache != nil {
+		// A failed cache write only costs us a refetch next time
+		_ = c.Cache.Put(url, body)
+	}
+
+	return body, nil
+}
+
+// fetch performs a GET against the API, retrying network errors, 5xx and 429
+// responses with jittered exponential backoff
+func (c *APIClient) fetch(ctx context.Context, url string) ([]byte, error) {
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			wait := c.Retry.backoff(attempt - 1)
+			var apiErr *APIError
+			if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > wait {
+				wait = apiErr.RetryAfter
+			}
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.fetchOnce(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		// Never retry once the caller has given up
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if !isRetryable(err) {
+			break
+		}
+	}
+
+	if attempts > 1 && isRetryable(lastErr) {
+		return nil, fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+	}
+	return nil, lastErr
+}
+
+// fetchOnce performs a single rate-limited GET
+func (c *APIClient) fetchOnce(ctx context.Context, url string) ([]byte, error) {
+	if c.Limiter != nil {
+		if err := c.Limiter.WaitContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+		return nil, &APIError{
+			URL:        url,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -108,8 +366,67 @@ func (c *APIClient) makeRequest(endpoint string) ([]byte, error) {
 	return body, nil
 }
 
-func (c *APIClient) GetDrivers() ([]Driver, error) {
-	data, err := c.makeRequest("drivers?session_key=latest")
+// Ping checks that the API answers, bypassing the cache and retries
+func (c *APIClient) Ping(ctx context.Context) error {
+	_, err := c.fetchOnce(ctx, fmt.Sprintf("%s/%s", c.BaseURL, "drivers?session_key=latest"))
+	return err
+}
+
+// cacheTTL decides how long a response for endpoint may be served from cache
+func (c *APIClient) cacheTTL(endpoint string) time.Duration {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	query, _ := url.ParseQuery(rawQuery)
+
+	for _, values := range query {
+		for _, value := range values {
+			if value == "latest" {
+				return LatestTTL
+			}
+		}
+	}
+
+	if path == "meetings" || path == "sessions" {
+		return ScheduleTTL
+	}
+
+	if key, err := strconv.Atoi(query.Get("session_key")); err == nil && c.isFinished(key) {
+		return CacheForever
+	}
+
+	return LiveTTL
+}
+
+// markFinished records which sessions ended long enough ago that their data is final
+func (c *APIClient) markFinished(sessions []OpenF1Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.finishedSessions == nil {
+		c.finishedSessions = make(map[int]bool)
+	}
+
+	cutoff := time.Now().Add(-finishedSessionGrace)
+	for _, session := range sessions {
+		if !session.DateEnd.IsZero() && session.DateEnd.Before(cutoff) {
+			c.finishedSessions[session.SessionKey] = true
+		}
+	}
+}
+
+func (c *APIClient) isFinished(sessionKey int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.finishedSessions[sessionKey]
+}
+
+// GetDrivers returns the drivers entered in the most recent session of the given season.
+func (c *APIClient) GetDrivers(year int) ([]Driver, error) {
+	return c.GetDriversContext(context.Background(), year)
+}
+
+// GetDriversContext is like GetDrivers but takes a context for cancellation
+func (c *APIClient) GetDriversContext(ctx context.Context, year int) ([]Driver, error) {
+	data, err := c.makeRequest(ctx, c.driversEndpoint(ctx, year))
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +449,8 @@ func (c *APIClient) GetDrivers() ([]Driver, error) {
 		result = append(result, Driver{
 			ID:            driver.DriverNumber,
 			Name:          driver.FullName,
+			LastName:      driver.LastName,
+			Acronym:       driver.NameAcronym,
 			Number:        driver.DriverNumber,
 			Team:          driver.TeamName,
 			Country:       driver.CountryCode,
@@ -145,53 +464,62 @@ func (c *APIClient) GetDrivers() ([]Driver, error) {
 	return result, nil
 }
 
-// F1 Points Systems
-var PointsSystem = map[int]int{
-	1: 25, 2: 18, 3: 15, 4: 12, 5: 10, 6: 8, 7: 6, 8: 4, 9: 2, 10: 1,
+// GetSessionDrivers returns the entry list of a single session, with the team
+// each driver raced for in it
+func (c *APIClient) GetSessionDrivers(sessionKey int) ([]OpenF1Driver, error) {
+	return c.GetSessionDriversContext(context.Background(), sessionKey)
 }
 
-var SprintPointsSystem = map[int]int{
-	1: 8, 2: 7, 3: 6, 4: 5, 5: 4, 6: 3, 7: 2, 8: 1,
-}
+// GetSessionDriversContext is like GetSessionDrivers but takes a context for cancellation
+func (c *APIClient) GetSessionDriversContext(ctx context.Context, sessionKey int) ([]OpenF1Driver, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("drivers?session_key=%d", sessionKey))
+	if err != nil {
+		return nil, err
+	}
 
-// Disqualified drivers for specific sessions
-var DisqualifiedDrivers = map[int][]int{
-	9998:  {16, 44, 10}, // Shanghai Race: Leclerc, Hamilton, Gasly
-	10028: {23, 30, 87}, // Miami Sprint: Albon, Lawson, Bearman
+	var drivers []OpenF1Driver
+	if err := json.Unmarshal(data, &drivers); err != nil {
+		return nil, fmt.Errorf("failed to parse drivers response: %w", err)
+	}
+	return drivers, nil
 }
 
-// Position adjustments due to disqualifications - when drivers are DSQ'd,
-// everyone behind them moves up and gets points for their new position
-var PositionAdjustments = map[int]map[int]int{
-	9693: {
-		12: 4, // Antonelli: 5th → 4th
-		23: 5, // Albon: 4th → 5th
-	},
-	9998: {
-		31: 5,  // Ocon: 7th → 5th
-		12: 6,  // Antonelli: 8th → 6th
-		23: 7,  // Albon: 9th → 7th
-		87: 8,  // Bearman: 10th → 8th
-		18: 9,  // Stroll: 12th → 9th
-		55: 10, // Sainz: 13th → 10th
-	},
-	10028: {
-		63: 4, // Russell: 5th → 4th
-		18: 5, // Stroll: 6th → 5th
-		22: 6, // Tsunoda: 7th → 6th
-		12: 7, // Antonelli: 8th → 7th
-		10: 8, // Gasly: 9th → 8th
-	},
+// driversEndpoint picks the session whose entry list represents the season.
+// The current season uses the latest session; past seasons use their final race.
+func (c *APIClient) driversEndpoint(ctx context.Context, year int) string {
+	if year == CurrentSeason() {
+		return "drivers?session_key=latest"
+	}
+
+	sessions, err := c.GetAllRaceAndSprintSessionsContext(ctx, year)
+	if err != nil || len(sessions) == 0 {
+		return "drivers?session_key=latest"
+	}
+
+	var last *OpenF1Session
+	for i, session := range sessions {
+		if session.DateStart.After(time.Now()) {
+			continue
+		}
+		if last == nil || session.DateStart.After(last.DateStart) {
+			last = &sessions[i]
+		}
+	}
+
+	if last == nil {
+		return "drivers?session_key=latest"
+	}
+
+	return fmt.Sprintf("drivers?session_key=%d", last.SessionKey)
 }
 
-// StandingData holds points and wins for standings calculation
-type StandingData struct {
-	Points int
-	Wins   int
+func (c *APIClient) GetRaceSessions(year int) ([]OpenF1Session, error) {
+	return c.GetRaceSessionsContext(context.Background(), year)
 }
 
-func (c *APIClient) GetRaceSessions() ([]OpenF1Session, error) {
-	data, err := c.makeRequest("sessions?session_type=Race&year=2025")
+// GetRaceSessionsContext is like GetRaceSessions but takes a context for cancellation
+func (c *APIClient) GetRaceSessionsContext(ctx context.Context, year int) ([]OpenF1Session, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("sessions?session_type=Race&year=%d", year))
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +528,7 @@ func (c *APIClient) GetRaceSessions() ([]OpenF1Session, error) {
 	if err := json.Unmarshal(data, &allSessions); err != nil {
 		return nil, fmt.Errorf("failed to parse sessions response: %w", err)
 	}
+	c.markFinished(allSessions)
 
 	// Filter for sessions with session_name "Race" (exclude "Sprint")
 	var raceSessions []OpenF1Session
@@ -212,8 +541,13 @@ func (c *APIClient) GetRaceSessions() ([]OpenF1Session, error) {
 	return raceSessions, nil
 }
 
-func (c *APIClient) GetSprintSessions() ([]OpenF1Session, error) {
-	data, err := c.makeRequest("sessions?session_type=Race&year=2025")
+func (c *APIClient) GetSprintSessions(year int) ([]OpenF1Session, error) {
+	return c.GetSprintSessionsContext(context.Background(), year)
+}
+
+// GetSprintSessionsContext is like GetSprintSessions but takes a context for cancellation
+func (c *APIClient) GetSprintSessionsContext(ctx context.Context, year int) ([]OpenF1Session, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("sessions?session_type=Race&year=%d", year))
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +556,7 @@ func (c *APIClient) GetSprintSessions() ([]OpenF1Session, error) {
 	if err := json.Unmarshal(data, &allSessions); err != nil {
 		return nil, fmt.Errorf("failed to parse sessions response: %w", err)
 	}
+	c.markFinished(allSessions)
 
 	// Filter for sessions with session_name "Sprint"
 	var sprintSessions []OpenF1Session
@@ -234,24 +569,71 @@ func (c *APIClient) GetSprintSessions() ([]OpenF1Session, error) {
 	return sprintSessions, nil
 }
 
-func (c *APIClient) GetAllRaceAndSprintSessions() ([]OpenF1Session, error) {
-	raceSessions, err := c.GetRaceSessions()
+// GetSessionsByType returns a season's sessions of one type ("Qualifying",
+// "Practice") in calendar order. Sprint qualifying has the type "Qualifying".
+func (c *APIClient) GetSessionsByType(year int, sessionType string) ([]OpenF1Session, error) {
+	return c.GetSessionsByTypeContext(context.Background(), year, sessionType)
+}
+
+// GetSessionsByTypeContext is like GetSessionsByType but takes a context for cancellation
+func (c *APIClient) GetSessionsByTypeContext(ctx context.Context, year int, sessionType string) ([]OpenF1Session, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("sessions?session_type=%s&year=%d", url.QueryEscape(sessionType), year))
 	if err != nil {
 		return nil, err
 	}
 
-	sprintSessions, err := c.GetSprintSessions()
+	var sessions []OpenF1Session
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse sessions response: %w", err)
+	}
+	c.markFinished(sessions)
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].DateStart.Before(sessions[j].DateStart)
+	})
+	return sessions, nil
+}
+
+func (c *APIClient) GetAllRaceAndSprintSessions(year int) ([]OpenF1Session, error) {
+	return c.GetAllRaceAndSprintSessionsContext(context.Background(), year)
+}
+
+// GetAllRaceAndSprintSessionsContext is like GetAllRaceAndSprintSessions but takes a context for cancellation
+func (c *APIClient) GetAllRaceAndSprintSessionsContext(ctx context.Context, year int) ([]OpenF1Session, error) {
+	raceSessions, err := c.GetRaceSessionsContext(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	sprintSessions, err := c.GetSprintSessionsContext(ctx, year)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return raceSessions, nil
 	}
 
 	allSessions := append(raceSessions, sprintSessions...)
+
+	// Keep sessions in calendar order so sprints sit next to their race
+	sort.SliceStable(allSessions, func(i, j int) bool {
+		return allSessions[i].DateStart.Before(allSessions[j].DateStart)
+	})
+
 	return allSessions, nil
 }
 
+// GetSessionResults approximates a classification from each driver's last
+// position sample. It downloads the whole position stream and knows nothing of
+// retirements or penalties, so it is only a fallback for GetSessionClassification.
 func (c *APIClient) GetSessionResults(sessionKey int) ([]OpenF1Position, error) {
+	return c.GetSessionResultsContext(context.Background(), sessionKey)
+}
+
+// GetSessionResultsContext is like GetSessionResults but takes a context for cancellation
+func (c *APIClient) GetSessionResultsContext(ctx context.Context, sessionKey int) ([]OpenF1Position, error) {
 	endpoint := fmt.Sprintf("position?session_key=%d", sessionKey)
-	data, err := c.makeRequest(endpoint)
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -274,220 +656,268 @@ func (c *APIClient) GetSessionResults(sessionKey int) ([]OpenF1Position, error)
 		result = append(result, pos)
 	}
 
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Position != result[j].Position {
+			return result[i].Position < result[j].Position
+		}
+		return result[i].DriverNumber < result[j].DriverNumber
+	})
+
 	return result, nil
 }
 
-func (c *APIClient) GetCurrentRaceSchedule() ([]Race, error) {
-	data, err := c.makeRequest("meetings?year=2025")
+// raceRounds numbers the championship rounds: meetings that hold a race, in
+// date order. It maps meeting keys to round numbers.
+func raceRounds(sessions []OpenF1Session) map[int]int {
+	var races []OpenF1Session
+	for _, session := range sessions {
+		if session.SessionName == "Race" {
+			races = append(races, session)
+		}
+	}
+	sort.SliceStable(races, func(i, j int) bool {
+		return races[i].DateStart.Before(races[j].DateStart)
+	})
+
+	rounds := make(map[int]int)
+	for _, race := range races {
+		if _, seen := rounds[race.MeetingKey]; !seen {
+			rounds[race.MeetingKey] = len(rounds) + 1
+		}
+	}
+	return rounds
+}
+
+// GetSessionClassification returns the official classification of a session
+// from the session_result endpoint, ordered by position with unclassified
+// drivers last. It is empty until OpenF1 publishes the result.
+func (c *APIClient) GetSessionClassification(sessionKey int) ([]OpenF1RaceResult, error) {
+	return c.GetSessionClassificationContext(context.Background(), sessionKey)
+}
+
+// GetSessionClassificationContext is like GetSessionClassification but takes a context for cancellation
+func (c *APIClient) GetSessionClassificationContext(ctx context.Context, sessionKey int) ([]OpenF1RaceResult, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("session_result?session_key=%d", sessionKey))
 	if err != nil {
 		return nil, err
 	}
 
-	var meetings []OpenF1Meeting
-	if err := json.Unmarshal(data, &meetings); err != nil {
-		return nil, fmt.Errorf("failed to parse meetings response: %w", err)
+	var results []OpenF1RaceResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse session result response: %w", err)
 	}
 
-	result := make([]Race, len(meetings))
-
-	for i, meeting := range meetings {
-		status := "upcoming"
-		if meeting.DateStart.Before(time.Now()) {
-			status = "completed"
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Position == 0) != (results[j].Position == 0) {
+			return results[j].Position == 0
 		}
+		return results[i].Position < results[j].Position
+	})
 
-		result[i] = Race{
-			Round:        i + 1,
-			Name:         meeting.MeetingOfficialName,
-			Circuit:      meeting.CircuitShortName,
-			Country:      meeting.CountryName,
-			Date:         meeting.DateStart,
-			Time:         meeting.DateStart.Format("15:04"),
-			Status:       status,
-			Winner:       "",
-			PolePosition: "",
-			FastestLap:   "",
-		}
-	}
+	return results, nil
+}
 
-	return result, nil
+// GetLaps returns the laps of a session in the order they were started. A
+// driverNumber of zero returns every driver's laps.
+func (c *APIClient) GetLaps(sessionKey, driverNumber int) ([]OpenF1Lap, error) {
+	return c.GetLapsContext(context.Background(), sessionKey, driverNumber)
 }
 
-// GetCurrentDriverStandings calculates real driver standings from race results
-func (c *APIClient) GetCurrentDriverStandings() ([]StandingEntry, error) {
-	// Get all race and sprint sessions for current year
-	sessions, err := c.GetAllRaceAndSprintSessions()
+// GetLapsContext is like GetLaps but takes a context for cancellation
+func (c *APIClient) GetLapsContext(ctx context.Context, sessionKey, driverNumber int) ([]OpenF1Lap, error) {
+	endpoint := fmt.Sprintf("laps?session_key=%d", sessionKey)
+	if driverNumber > 0 {
+		endpoint += fmt.Sprintf("&driver_number=%d", driverNumber)
+	}
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get all drivers first
-	drivers, err := c.GetDrivers()
+	var laps []OpenF1Lap
+	if err := json.Unmarshal(data, &laps); err != nil {
+		return nil, fmt.Errorf("failed to parse laps response: %w", err)
+	}
+
+	sort.SliceStable(laps, func(i, j int) bool {
+		return laps[i].DateStart.Before(laps[j].DateStart)
+	})
+	return laps, nil
+}
+
+// GetStints returns the tyre stints of a session, or one driver's when
+// driverNumber isn't zero, in driver and stint order
+func (c *APIClient) GetStints(sessionKey, driverNumber int) ([]OpenF1Stint, error) {
+	return c.GetStintsContext(context.Background(), sessionKey, driverNumber)
+}
+
+// GetStintsContext is like GetStints but takes a context for cancellation
+func (c *APIClient) GetStintsContext(ctx context.Context, sessionKey, driverNumber int) ([]OpenF1Stint, error) {
+	endpoint := fmt.Sprintf("stints?session_key=%d", sessionKey)
+	if driverNumber > 0 {
+		endpoint += fmt.Sprintf("&driver_number=%d", driverNumber)
+	}
+	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	// Initialize driver standings map
-	driverPoints := make(map[int]*StandingData)
-	driverNames := make(map[int]string)
-	driverTeams := make(map[int]string)
+	var stints []OpenF1Stint
+	if err := json.Unmarshal(data, &stints); err != nil {
+		return nil, fmt.Errorf("failed to parse stints response: %w", err)
+	}
 
-	for _, driver := range drivers {
-		driverPoints[driver.Number] = &StandingData{
-			Points: 0,
-			Wins:   0,
+	sort.SliceStable(stints, func(i, j int) bool {
+		if stints[i].DriverNumber != stints[j].DriverNumber {
+			return stints[i].DriverNumber < stints[j].DriverNumber
 		}
-		driverNames[driver.Number] = driver.Name
-		driverTeams[driver.Number] = driver.Team
+		return stints[i].StintNumber < stints[j].StintNumber
+	})
+	return stints, nil
+}
+
+// GetWeather returns a session's weather readings in time order
+func (c *APIClient) GetWeather(sessionKey int) ([]OpenF1Weather, error) {
+	return c.GetWeatherContext(context.Background(), sessionKey)
+}
+
+// GetWeatherContext is like GetWeather but takes a context for cancellation
+func (c *APIClient) GetWeatherContext(ctx context.Context, sessionKey int) ([]OpenF1Weather, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("weather?session_key=%d", sessionKey))
+	if err != nil {
+		return nil, err
 	}
 
-	// Process each completed session (race or sprint)
-	for _, session := range sessions {
-		// Only process completed sessions (before current time)
-		if session.DateStart.After(time.Now()) {
-			continue
-		}
+	var readings []OpenF1Weather
+	if err := json.Unmarshal(data, &readings); err != nil {
+		return nil, fmt.Errorf("failed to parse weather response: %w", err)
+	}
 
-		results, err := c.GetSessionResults(session.SessionKey)
-		if err != nil {
-			// Silently skip sessions that don't have results yet
-			continue
-		}
+	sort.SliceStable(readings, func(i, j int) bool {
+		return readings[i].Date.Before(readings[j].Date)
+	})
+	return readings, nil
+}
 
-		// Determine which points system to use based on session name
-		var pointsSystem map[int]int
-		if session.SessionName == "Sprint" {
-			pointsSystem = SprintPointsSystem
-		} else {
-			pointsSystem = PointsSystem
-		}
+// GetPits returns a session's pit lane visits in time order
+func (c *APIClient) GetPits(sessionKey int) ([]OpenF1Pit, error) {
+	return c.GetPitsContext(context.Background(), sessionKey)
+}
 
-		// Award points based on finishing position
-		for _, result := range results {
-			if standing, exists := driverPoints[result.DriverNumber]; exists {
-				// Check if driver was disqualified from this session
-				dsqDrivers, sessionHasDSQ := DisqualifiedDrivers[session.SessionKey]
-				isDisqualified := false
-				if sessionHasDSQ {
-					for _, dsqDriver := range dsqDrivers {
-						if dsqDriver == result.DriverNumber {
-							isDisqualified = true
-							break
-						}
-					}
-				}
-
-				// Only award points if not disqualified
-				if !isDisqualified {
-					finalPosition := result.Position
-
-					// Check if driver's position was adjusted due to DSQs
-					if adjustments, hasAdjustments := PositionAdjustments[session.SessionKey]; hasAdjustments {
-						if newPosition, wasAdjusted := adjustments[result.DriverNumber]; wasAdjusted {
-							finalPosition = newPosition
-						}
-					}
-
-					if points, hasPoints := pointsSystem[finalPosition]; hasPoints {
-						standing.Points += points
-					}
-					// Only count wins for main races, not sprints
-					if finalPosition == 1 && session.SessionName == "Race" {
-						standing.Wins++
-					}
-				}
-			}
-		}
+// GetPitsContext is like GetPits but takes a context for cancellation
+func (c *APIClient) GetPitsContext(ctx context.Context, sessionKey int) ([]OpenF1Pit, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("pit?session_key=%d", sessionKey))
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert to sorted standings
-	var standings []StandingEntry
-	for driverNumber, standing := range driverPoints {
-		if driverName, exists := driverNames[driverNumber]; exists {
-			standings = append(standings, StandingEntry{
-				Driver: driverName,
-				Team:   driverTeams[driverNumber],
-				Points: standing.Points,
-				Wins:   standing.Wins,
-			})
-		}
+	var pits []OpenF1Pit
+	if err := json.Unmarshal(data, &pits); err != nil {
+		return nil, fmt.Errorf("failed to parse pit response: %w", err)
 	}
 
-	// Sort by points (highest first), then by wins
-	for i := 0; i < len(standings)-1; i++ {
-		for j := i + 1; j < len(standings); j++ {
-			if standings[j].Points > standings[i].Points ||
-				(standings[j].Points == standings[i].Points && standings[j].Wins > standings[i].Wins) {
-				standings[i], standings[j] = standings[j], standings[i]
-			}
-		}
+	sort.SliceStable(pits, func(i, j int) bool {
+		return pits[i].Date.Before(pits[j].Date)
+	})
+	return pits, nil
+}
+
+// GetRaceControl returns a session's race control messages in time order
+func (c *APIClient) GetRaceControl(sessionKey int) ([]OpenF1RaceControl, error) {
+	return c.GetRaceControlContext(context.Background(), sessionKey)
+}
+
+// GetRaceControlContext is like GetRaceControl but takes a context for cancellation
+func (c *APIClient) GetRaceControlContext(ctx context.Context, sessionKey int) ([]OpenF1RaceControl, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("race_control?session_key=%d", sessionKey))
+	if err != nil {
+		return nil, err
 	}
 
-	// Add position and gap information
-	for i := range standings {
-		standings[i].Position = i + 1
-		if i == 0 {
-			standings[i].Gap = "Leader"
-		} else {
-			standings[i].Gap = fmt.Sprintf("-%d", standings[0].Points-standings[i].Points)
-		}
+	var messages []OpenF1RaceControl
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse race control response: %w", err)
 	}
 
-	return standings, nil
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Date.Before(messages[j].Date)
+	})
+	return messages, nil
+}
+
+// GetMeetings returns every meeting (race weekend or test) of the given season
+func (c *APIClient) GetMeetings(year int) ([]OpenF1Meeting, error) {
+	return c.GetMeetingsContext(context.Background(), year)
 }
 
-// GetCurrentConstructorStandings calculates constructor standings from driver standings
-func (c *APIClient) GetCurrentConstructorStandings() ([]StandingEntry, error) {
-	// Get driver standings first
-	driverStandings, err := c.GetCurrentDriverStandings()
+// GetMeetingsContext is like GetMeetings but takes a context for cancellation
+func (c *APIClient) GetMeetingsContext(ctx context.Context, year int) ([]OpenF1Meeting, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("meetings?year=%d", year))
 	if err != nil {
 		return nil, err
 	}
 
-	// Group drivers by team and sum points
-	teamPoints := make(map[string]int)
-	teamWins := make(map[string]int)
-	teamCountries := make(map[string]string)
+	var meetings []OpenF1Meeting
+	if err := json.Unmarshal(data, &meetings); err != nil {
+		return nil, fmt.Errorf("failed to parse meetings response: %w", err)
+	}
+	return meetings, nil
+}
 
-	for _, standing := range driverStandings {
-		teamPoints[standing.Team] += standing.Points
-		teamWins[standing.Team] += standing.Wins
-		// Use first driver's team to determine country (approximate)
-		if _, exists := teamCountries[standing.Team]; !exists {
-			teamCountries[standing.Team] = standing.Team // Fallback to team name
-		}
+// GetRaceSchedule returns every meeting of the given season
+func (c *APIClient) GetRaceSchedule(year int) ([]Race, error) {
+	return c.GetRaceScheduleContext(context.Background(), year)
+}
+
+// GetRaceScheduleContext is like GetRaceSchedule but takes a context for cancellation
+func (c *APIClient) GetRaceScheduleContext(ctx context.Context, year int) ([]Race, error) {
+	meetings, err := c.GetMeetingsContext(ctx, year)
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert to sorted standings
-	var standings []StandingEntry
-	for team, points := range teamPoints {
-		standings = append(standings, StandingEntry{
-			Driver: team, // Using Driver field for team name
-			Team:   teamCountries[team],
-			Points: points,
-			Wins:   teamWins[team],
-		})
+	// Only meetings with a race are championship rounds, and a meeting's
+	// status follows its race session. The rest (pre-season testing) are kept
+	// but flagged and fall back to their start date.
+	sessions, err := c.GetRaceSessionsContext(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	rounds := raceRounds(sessions)
+	raceSessions := make(map[int]OpenF1Session)
+	for _, session := range sessions {
+		raceSessions[session.MeetingKey] = session
 	}
 
-	// Sort by points (highest first), then by wins
-	for i := 0; i < len(standings)-1; i++ {
-		for j := i + 1; j < len(standings); j++ {
-			if standings[j].Points > standings[i].Points ||
-				(standings[j].Points == standings[i].Points && standings[j].Wins > standings[i].Wins) {
-				standings[i], standings[j] = standings[j], standings[i]
-			}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		return meetings[i].DateStart.Before(meetings[j].DateStart)
+	})
+
+	result := make([]Race, len(meetings))
+
+	for i, meeting := range meetings {
+		status := "upcoming"
+		session, hasRace := raceSessions[meeting.MeetingKey]
+		if hasRace {
+			status = raceStatus(session.toSession().State())
+		} else if meeting.DateStart.Before(time.Now()) {
+			status = "completed"
 		}
-	}
 
-	// Add position and gap information
-	for i := range standings {
-		standings[i].Position = i + 1
-		if i == 0 {
-			standings[i].Gap = "Leader"
-		} else {
-			standings[i].Gap = fmt.Sprintf("-%d", standings[0].Points-standings[i].Points)
+		result[i] = Race{
+			Round:        rounds[meeting.MeetingKey],
+			Testing:      !hasRace,
+			Name:         meeting.MeetingOfficialName,
+			Circuit:      meeting.CircuitShortName,
+			Country:      meeting.CountryName,
+			Date:         meeting.DateStart,
+			Time:         meeting.DateStart.Format("15:04"),
+			Status:       status,
+			Winner:       "",
+			PolePosition: "",
+			FastestLap:   "",
 		}
 	}
 
-	return standings, nil
+	return result, nil
 }
@@ -0,0 +1,172 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache lifetimes for the different kinds of OpenF1 data
+const (
+	// CacheForever marks data that never changes once written (finished sessions)
+	CacheForever time.Duration = -1
+	// LatestTTL applies to "latest" queries, which move as soon as a new session starts
+	LatestTTL = 2 * time.Minute
+	// ScheduleTTL applies to meeting and session listings
+	ScheduleTTL = 1 * time.Hour
+	// LiveTTL applies to session data that may still be changing
+	LiveTTL = 5 * time.Minute
+)
+
+// ResponseCache stores raw API responses on disk so repeat runs skip the network
+type ResponseCache struct {
+	Dir string
+}
+
+// CacheStats summarises the contents of a ResponseCache
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Bytes   int64
+	Oldest  time.Time
+	Newest  time.Time
+}
+
+// cacheEntry is the on-disk format of a single cached response
+type cacheEntry struct {
+	URL      string          `json:"url"`
+	StoredAt time.Time       `json:"stored_at"`
+	Body     json.RawMessage `json:"body"`
+}
+
+// DefaultCacheDir returns the cache directory inside the user cache dir
+func DefaultCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user cache dir: %w", err)
+	}
+	return filepath.Join(base, "f1cli", "http"), nil
+}
+
+// NewResponseCache returns a cache rooted at dir
+func NewResponseCache(dir string) *ResponseCache {
+	return &ResponseCache{Dir: dir}
+}
+
+func (rc *ResponseCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(rc.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached body for url if it is younger than ttl.
+// A ttl of CacheForever never expires.
+func (rc *ResponseCache) Get(url string, ttl time.Duration) ([]byte, bool) {
+	raw, err := os.ReadFile(rc.path(url))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.URL != url {
+		return nil, false
+	}
+
+	if ttl != CacheForever && time.Since(entry.StoredAt) > ttl {
+		return nil, false
+	}
+
+	return entry.Body, true
+}
+
+// Put stores body for url. Bodies that are not valid JSON are not cached.
+func (rc *ResponseCache) Put(url string, body []byte) error {
+	if !json.Valid(body) {
+		return fmt.Errorf("refusing to cache non-JSON response for %s", url)
+	}
+
+	if err := os.MkdirAll(rc.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	raw, err := json.Marshal(cacheEntry{URL: url, StoredAt: time.Now(), Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	// Write to a temp file first so a concurrent reader never sees a partial entry
+	tmp, err := os.CreateTemp(rc.Dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	tmp.Close()
+
+	return os.Rename(tmp.Name(), rc.path(url))
+}
+
+// Clear removes every cached response and returns how many were deleted
+func (rc *ResponseCache) Clear() (int, error) {
+	entries, err := os.ReadDir(rc.Dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache dir: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(rc.Dir, entry.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// Stats reports how many responses are cached and how much space they use
+func (rc *ResponseCache) Stats() (CacheStats, error) {
+	stats := CacheStats{Dir: rc.Dir}
+
+	entries, err := os.ReadDir(rc.Dir)
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, fmt.Errorf("failed to read cache dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+	}
+
+	return stats, nil
+}
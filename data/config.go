@@ -0,0 +1,48 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config holds user preferences read from the config file
+type Config struct {
+	// Source is the default data source name, e.g. "openf1"
+	Source string `json:"source"`
+	// Penalties is an override penalties ledger merged into the built-in one
+	Penalties string `json:"penalties,omitempty"`
+}
+
+// DefaultConfigPath returns the config file location inside the user config dir
+func DefaultConfigPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config dir: %w", err)
+	}
+	return filepath.Join(base, "f1cli", "config.json"), nil
+}
+
+// LoadConfig reads the config file at path. A missing file is not an error
+// and yields the defaults.
+func LoadConfig(path string) (Config, error) {
+	config := Config{Source: DefaultSourceName}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return config, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if config.Source == "" {
+		config.Source = DefaultSourceName
+	}
+
+	return config, nil
+}
@@ -0,0 +1,103 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+// addFinish records a race finish in a countback tally
+func addFinish(countback []int, position int) []int {
+	if position < 1 {
+		return countback
+	}
+	for len(countback) < position {
+		countback = append(countback, 0)
+	}
+	countback[position-1]++
+	return countback
+}
+
+// countsForCountback reports whether a result counts towards tie-breaks:
+// classified finishes in main races, not sprints
+func countsForCountback(session Session, result ScoredResult) bool {
+	return session.Name == "Race" && !result.Disqualified && result.ClassifiedPosition > 0
+}
+
+// compareCountback compares two tallies the way the FIA breaks ties: most
+// wins, then most second places, and so on. It returns the first position
+// that differs and how many more of it a has than b; 0, 0 means a dead heat.
+func compareCountback(a, b []int) (position, diff int) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var countA, countB int
+		if i < len(a) {
+			countA = a[i]
+		}
+		if i < len(b) {
+			countB = b[i]
+		}
+		if countA != countB {
+			return i + 1, countA - countB
+		}
+	}
+	return 0, 0
+}
+
+// ordinalPlaces names a finishing position for tie-break explanations
+func ordinalPlaces(position int) string {
+	if position == 1 {
+		return "wins"
+	}
+
+	suffix := "th"
+	switch {
+	case position%100 >= 11 && position%100 <= 13:
+	case position%10 == 1:
+		suffix = "st"
+	case position%10 == 2:
+		suffix = "nd"
+	case position%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s places", position, suffix)
+}
+
+// orderStandings sorts standings by points and breaks ties on countback. Entries
+// that can't be separated share a position, and every tie records why it was
+// resolved the way it was. Positions and gaps are filled in.
+func orderStandings(standings []StandingEntry) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		_, diff := compareCountback(standings[i].Countback, standings[j].Countback)
+		return diff > 0
+	})
+
+	for i := range standings {
+		standings[i].Position = i + 1
+		standings[i].Tied = false
+		standings[i].TieBreak = ""
+		if i == 0 || standings[i].Points != standings[i-1].Points {
+			continue
+		}
+
+		above := &standings[i-1]
+		position, diff := compareCountback(above.Countback, standings[i].Countback)
+		if diff == 0 {
+			standings[i].Position = above.Position
+			standings[i].Tied = true
+			above.Tied = true
+			standings[i].TieBreak = fmt.Sprintf("Level with %s on points and every finishing position", above.Driver)
+			continue
+		}
+
+		ahead := 0
+		if position-1 < len(above.Countback) {
+			ahead = above.Countback[position-1]
+		}
+		standings[i].TieBreak = fmt.Sprintf("Behind %s on countback: fewer %s (%d vs %d)",
+			above.Driver, ordinalPlaces(position), ahead-diff, ahead)
+	}
+
+	fillStandingGaps(standings)
+}
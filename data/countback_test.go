@@ -0,0 +1,85 @@
+package data
+
+import "testing"
+
+func TestOrderStandingsCountback(t *testing.T) {
+	tests := []struct {
+		name      string
+		standings []StandingEntry
+		// want lists driver, position label and tie-break, in order
+		want [][3]string
+	}{
+		{
+			name: "settled on wins",
+			standings: []StandingEntry{
+				{Driver: "Piastri", Points: 43, Countback: []int{0, 2}},
+				{Driver: "Norris", Points: 43, Countback: []int{1, 0, 1}},
+			},
+			want: [][3]string{
+				{"Norris", "1", ""},
+				{"Piastri", "2", "Behind Norris on countback: fewer wins (0 vs 1)"},
+			},
+		},
+		{
+			name: "settled on second places",
+			standings: []StandingEntry{
+				{Driver: "Leader", Points: 60, Countback: []int{2}},
+				{Driver: "Russell", Points: 40, Countback: []int{1, 1, 3}},
+				{Driver: "Leclerc", Points: 40, Countback: []int{1, 2}},
+			},
+			want: [][3]string{
+				{"Leader", "1", ""},
+				{"Leclerc", "2", ""},
+				{"Russell", "3", "Behind Leclerc on countback: fewer 2nd places (1 vs 2)"},
+			},
+		},
+		{
+			name: "shared place",
+			standings: []StandingEntry{
+				{Driver: "Leader", Points: 30, Countback: []int{1}},
+				{Driver: "Albon", Points: 15, Countback: []int{0, 0, 1}},
+				{Driver: "Back", Points: 2, Countback: []int{0, 0, 0, 0, 0, 0, 0, 0, 1}},
+				// A longer tally of zeros is still level
+				{Driver: "Stroll", Points: 15, Countback: []int{0, 0, 1, 0}},
+			},
+			want: [][3]string{
+				{"Leader", "1", ""},
+				{"Albon", "=2", ""},
+				{"Stroll", "=2", "Level with Albon on points and every finishing position"},
+				{"Back", "4", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderStandings(tt.standings)
+			if len(tt.standings) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d", len(tt.standings), len(tt.want))
+			}
+			for i, want := range tt.want {
+				entry := tt.standings[i]
+				got := [3]string{entry.Driver, entry.PositionLabel(), entry.TieBreak}
+				if got != want {
+					t.Errorf("entry %d = %q, want %q", i, got, want)
+				}
+			}
+			if gap := tt.standings[0].Gap; gap != "Leader" {
+				t.Errorf("leader's gap = %q", gap)
+			}
+		})
+	}
+}
+
+func TestOrdinalPlaces(t *testing.T) {
+	tests := map[int]string{
+		1: "wins", 2: "2nd places", 3: "3rd places", 4: "4th places",
+		11: "11th places", 12: "12th places", 13: "13th places",
+		21: "21st places", 22: "22nd places",
+	}
+	for position, want := range tests {
+		if got := ordinalPlaces(position); got != want {
+			t.Errorf("ordinalPlaces(%d) = %q, want %q", position, got, want)
+		}
+	}
+}
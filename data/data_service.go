@@ -1,38 +1,112 @@
 package data
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strings"
+	"os"
+	"sync"
 )
 
-// DataService provides F1 data from OpenF1 API
+// DataService provides F1 data for a single season from a pluggable DataSource
 type DataService struct {
-	apiClient *APIClient
+	source DataSource
+	season int
+	// Workers bounds concurrent session fetches; zero means DefaultFetchWorkers
+	Workers int
+	// penalties holds the stewards' decisions applied when scoring sessions
+	penalties *Ledger
+
+	// mu guards the scored sessions kept for scoredSeason and the practice
+	// entry lists kept for entrantsSeason
+	mu             sync.Mutex
+	scored         []ScoredSession
+	scoredSeason   int
+	entrants       []sessionEntrants
+	entrantsSeason int
+
+	// fastestMu guards the fastest laps found for finished sessions, by
+	// session key, so marking a classification and showing it share one download
+	fastestMu   sync.Mutex
+	fastestLaps map[int]*FastestLap
 }
 
+// NewDataService returns an OpenF1-backed service for the current season
 func NewDataService() *DataService {
+	return NewDataServiceWithSource(NewOpenF1Source(NewAPIClient()), CurrentSeason())
+}
+
+// NewDataServiceForSeason returns an OpenF1-backed service for the given championship year
+func NewDataServiceForSeason(year int) *DataService {
+	return NewDataServiceWithSource(NewOpenF1Source(NewAPIClient()), year)
+}
+
+// NewDataServiceWithSource returns a service reading from source
+func NewDataServiceWithSource(source DataSource, year int) *DataService {
 	return &DataService{
-		apiClient: NewAPIClient(),
+		source:    source,
+		season:    year,
+		penalties: BuiltinLedger(),
 	}
 }
 
-func (ds *DataService) GetDriverStandings() ([]StandingEntry, error) {
-	return ds.apiClient.GetCurrentDriverStandings()
+// Source returns the backend the service reads from
+func (ds *DataService) Source() DataSource {
+	return ds.source
+}
+
+// Season returns the championship year the service reports on
+func (ds *DataService) Season() int {
+	return ds.season
+}
+
+// SetSeason switches the service to another championship year
+func (ds *DataService) SetSeason(year int) error {
+	if err := ds.ValidateSeason(year); err != nil {
+		return err
+	}
+	ds.season = year
+	return nil
 }
 
-func (ds *DataService) GetConstructorStandings() ([]StandingEntry, error) {
-	return ds.apiClient.GetCurrentConstructorStandings()
+// ValidateSeason reports whether the data source has data for the given year
+func (ds *DataService) ValidateSeason(year int) error {
+	first := ds.source.FirstSeason()
+	if year < first || year > CurrentSeason()+1 {
+		return fmt.Errorf("season %d is not available from %s (supported: %d-%d)",
+			year, ds.source.Name(), first, CurrentSeason()+1)
+	}
+	return nil
 }
 
+// GetDrivers returns everyone who has raced in the season with their
+// championship points and wins
 func (ds *DataService) GetDrivers() ([]Driver, error) {
-	drivers, err := ds.apiClient.GetDrivers()
+	return ds.GetDriversContext(context.Background())
+}
+
+// GetDriversContext is like GetDrivers but takes a context for cancellation
+func (ds *DataService) GetDriversContext(ctx context.Context) ([]Driver, error) {
+	drivers, err := ds.source.Drivers(ctx, ds.season)
 	if err != nil {
 		return nil, err
 	}
 
+	// Sources with official standings already list every entrant; otherwise
+	// add the drivers who raced earlier but aren't in the latest entry list
+	if _, official := ds.source.(StandingsSource); !official {
+		if roster, err := ds.GetRosterContext(ctx); err != nil {
+			log.Printf("Warning: Could not build the season roster: %v", err)
+		} else {
+			drivers = make([]Driver, len(roster))
+			for i, entry := range roster {
+				drivers[i] = entry.Driver
+			}
+		}
+	}
+
 	// Enrich with standings data
-	standings, err := ds.apiClient.GetCurrentDriverStandings()
+	standings, err := ds.GetDriverStandingsContext(ctx)
 	if err != nil {
 		log.Printf("Warning: Could not get standings data: %v", err)
 		return drivers, nil
@@ -53,36 +127,82 @@ func (ds *DataService) GetDrivers() ([]Driver, error) {
 	return drivers, nil
 }
 
-// GetDriverByName finds a driver by name from OpenF1 API
+// GetSeasonDrivers returns the season's latest entry list, without championship
+// data. Use GetRoster for everyone who has raced.
+func (ds *DataService) GetSeasonDrivers() ([]Driver, error) {
+	return ds.GetSeasonDriversContext(context.Background())
+}
+
+// GetSeasonDriversContext is like GetSeasonDrivers but takes a context for cancellation
+func (ds *DataService) GetSeasonDriversContext(ctx context.Context) ([]Driver, error) {
+	return ds.source.Drivers(ctx, ds.season)
+}
+
+// GetDriverByName finds a driver by name, surname, acronym or car number; see ResolveDriver
 func (ds *DataService) GetDriverByName(name string) (*Driver, error) {
-	drivers, err := ds.GetDrivers()
+	return ds.GetDriverByNameContext(context.Background(), name)
+}
+
+// GetDriverByNameContext is like GetDriverByName but takes a context for cancellation
+func (ds *DataService) GetDriverByNameContext(ctx context.Context, name string) (*Driver, error) {
+	drivers, err := ds.GetDriversContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, driver := range drivers {
-		if strings.EqualFold(driver.Name, name) {
-			return &driver, nil
-		}
+	driver, err := ResolveDriver(drivers, name)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, fmt.Errorf("driver '%s' not found", name)
+	return &driver, nil
 }
 
-// GetRaceSchedule returns race schedule from OpenF1 API
+// GetRaceSchedule returns the season's race schedule
 func (ds *DataService) GetRaceSchedule() ([]Race, error) {
-	return ds.apiClient.GetCurrentRaceSchedule()
+	return ds.GetRaceScheduleContext(context.Background())
+}
+
+// GetRaceScheduleContext is like GetRaceSchedule but takes a context for cancellation
+func (ds *DataService) GetRaceScheduleContext(ctx context.Context) ([]Race, error) {
+	return ds.source.Schedule(ctx, ds.season)
+}
+
+// GetRaceScheduleDetails returns the season's race schedule with the pole
+// sitter and fastest lap of every finished race. Sources whose schedule
+// lacks them cost a request or two per race, so only use it to show them.
+func (ds *DataService) GetRaceScheduleDetails() ([]Race, error) {
+	return ds.GetRaceScheduleDetailsContext(context.Background())
+}
+
+// GetRaceScheduleDetailsContext is like GetRaceScheduleDetails but takes a context for cancellation
+func (ds *DataService) GetRaceScheduleDetailsContext(ctx context.Context) ([]Race, error) {
+	races, err := ds.GetRaceScheduleContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ds.fillPolePositions(ctx, races); err != nil {
+		return nil, err
+	}
+	if err := ds.fillFastestLaps(ctx, races); err != nil {
+		return nil, err
+	}
+	return races, nil
 }
 
-// GetNextRace returns the next upcoming race from OpenF1 API
+// GetNextRace returns the next upcoming race
 func (ds *DataService) GetNextRace() (*Race, error) {
-	races, err := ds.GetRaceSchedule()
+	return ds.GetNextRaceContext(context.Background())
+}
+
+// GetNextRaceContext is like GetNextRace but takes a context for cancellation
+func (ds *DataService) GetNextRaceContext(ctx context.Context) (*Race, error) {
+	races, err := ds.GetRaceScheduleContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, race := range races {
-		if race.Status == "upcoming" {
+		if race.Status == "upcoming" && !race.Testing {
 			return &race, nil
 		}
 	}
@@ -90,16 +210,21 @@ func (ds *DataService) GetNextRace() (*Race, error) {
 	return nil, fmt.Errorf("no upcoming races found")
 }
 
-// GetLastRace returns the most recent completed race from OpenF1 API
+// GetLastRace returns the most recent completed race
 func (ds *DataService) GetLastRace() (*Race, error) {
-	races, err := ds.GetRaceSchedule()
+	return ds.GetLastRaceContext(context.Background())
+}
+
+// GetLastRaceContext is like GetLastRace but takes a context for cancellation
+func (ds *DataService) GetLastRaceContext(ctx context.Context) (*Race, error) {
+	races, err := ds.GetRaceScheduleContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var lastRace *Race
 	for _, race := range races {
-		if race.Status == "completed" {
+		if !race.Testing && (race.Status == "completed" || race.Status == "provisional") {
 			lastRace = &race
 		}
 	}
@@ -111,18 +236,112 @@ func (ds *DataService) GetLastRace() (*Race, error) {
 	return nil, fmt.Errorf("no completed races found")
 }
 
+// Penalties returns the stewards' decisions ledger used for scoring
+func (ds *DataService) Penalties() *Ledger {
+	return ds.penalties
+}
+
+// SetPenalties replaces the stewards' decisions ledger used for scoring
+func (ds *DataService) SetPenalties(ledger *Ledger) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.penalties = ledger
+	ds.scored = nil
+}
+
+// ScoreSession scores a session classification with the service's penalties ledger
+func (ds *DataService) ScoreSession(session Session, results []SessionResult) []ScoredResult {
+	return ScoreSession(session, results, ds.penalties)
+}
+
 // GetSourceName returns the name of the data source
 func (ds *DataService) GetSourceName() string {
-	return "OpenF1 API"
+	return ds.source.Name()
+}
+
+// GetSourceURL returns the base URL of the source's API, or "" for a source
+// not served over HTTP
+func (ds *DataService) GetSourceURL() string {
+	if source, ok := ds.source.(HTTPSource); ok {
+		return source.Client().BaseURL
+	}
+	return ""
 }
 
 // IsOnline checks if the API source is accessible
 func (ds *DataService) IsOnline() bool {
-	_, err := ds.apiClient.makeRequest("drivers?session_key=latest")
-	return err == nil
+	return ds.IsOnlineContext(context.Background())
+}
+
+// IsOnlineContext is like IsOnline but takes a context for cancellation
+func (ds *DataService) IsOnlineContext(ctx context.Context) bool {
+	return ds.source.Ping(ctx) == nil
+}
+
+// GetSessions returns the season's race and sprint sessions
+func (ds *DataService) GetSessions() ([]Session, error) {
+	return ds.GetSessionsContext(context.Background())
+}
+
+// GetSessionsContext is like GetSessions but takes a context for cancellation
+func (ds *DataService) GetSessionsContext(ctx context.Context) ([]Session, error) {
+	return ds.source.Sessions(ctx, ds.season)
 }
 
-// GetAPIClient returns the underlying API client for advanced operations
-func (ds *DataService) GetAPIClient() *APIClient {
-	return ds.apiClient
+// GetClassification returns the final order of a session
+func (ds *DataService) GetClassification(session Session) ([]SessionResult, error) {
+	return ds.GetClassificationContext(context.Background(), session)
+}
+
+// GetClassificationContext is like GetClassification but takes a context for cancellation
+func (ds *DataService) GetClassificationContext(ctx context.Context, session Session) ([]SessionResult, error) {
+	return ds.classification(ctx, session)
+}
+
+// classification fetches a session's results and marks the fastest lap when
+// the source leaves it out
+func (ds *DataService) classification(ctx context.Context, session Session) ([]SessionResult, error) {
+	results, err := ds.source.Classification(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return ds.withFastestLap(ctx, session, results)
+}
+
+// GetCache returns the response cache, or nil when the source has none
+func (ds *DataService) GetCache() *ResponseCache {
+	if cached, ok := ds.source.(CachingSource); ok {
+		return cached.Cache()
+	}
+	return nil
+}
+
+// DisableCache makes every request go to the network
+func (ds *DataService) DisableCache() {
+	if cached, ok := ds.source.(CachingSource); ok {
+		cached.SetCache(nil)
+	}
+}
+
+// Record saves every API response to dir while commands run normally
+func (ds *DataService) Record(dir string) error {
+	source, ok := ds.source.(HTTPSource)
+	if !ok {
+		return fmt.Errorf("%s does not support recording", ds.source.Name())
+	}
+	EnableRecording(source.Client(), dir)
+	return nil
+}
+
+// Replay serves every API response from recordings in dir instead of the network
+func (ds *DataService) Replay(dir string) error {
+	source, ok := ds.source.(HTTPSource)
+	if !ok {
+		return fmt.Errorf("%s does not support replay", ds.source.Name())
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("replay directory: %w", err)
+	}
+	EnableReplay(source.Client(), dir)
+	return nil
 }
@@ -0,0 +1,272 @@
+package data
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DegradationMinLaps is the fewest clean laps a stint needs for its
+// degradation to be fitted
+const DegradationMinLaps = 5
+
+// FuelEffect is roughly how much quicker a car gets per lap as it burns fuel,
+// in seconds. Within a stint it hides tyre wear, so it is added back to every
+// fitted slope.
+const FuelEffect = 0.03
+
+// trafficGap is how close behind another car a driver may start a lap before
+// the lap counts as run in traffic
+const trafficGap = 1500 * time.Millisecond
+
+// DegradationFit is a straight line through lap time against tyre age
+type DegradationFit struct {
+	// Slope is how many seconds a lap the tyres lose per lap of age, with the
+	// fuel effect taken out; Interval is the half-width of its 95% confidence
+	// interval, zero when there are too few laps to tell
+	Slope    float64
+	Interval float64
+	Laps     int
+	Stints   int
+}
+
+// Significant reports whether the fit can tell the slope apart from no
+// degradation at all
+func (f DegradationFit) Significant() bool {
+	return f.Interval > 0 && math.Abs(f.Slope) > f.Interval
+}
+
+// StintDegradation is the degradation of one driver's stint
+type StintDegradation struct {
+	Stint Stint
+	// Laps are the clean laps the fit was made from
+	Laps []Lap
+	Fit  DegradationFit
+}
+
+// CompoundDegradation pools every stint on one compound
+type CompoundDegradation struct {
+	Compound string
+	Drivers  int
+	Fit      DegradationFit
+}
+
+// DegradationExclusions counts the laps left out of the fits, by reason
+type DegradationExclusions struct {
+	FirstLap    int
+	PitInOut    int
+	Neutralised int
+	Traffic     int
+	Slow        int
+}
+
+// Degradation is a session's tyre degradation, stint by stint and pooled by compound
+type Degradation struct {
+	Stints     []StintDegradation
+	Compounds  []CompoundDegradation
+	Exclusions DegradationExclusions
+}
+
+// AnalyseDegradation fits lap time against tyre age for every stint with
+// enough clean laps. The opening lap, out and in laps, laps touched by a
+// neutralised period, laps started within 1.5s of another car and laps more
+// than 5% slower than the stint's median are left out. Compounds are fitted
+// with one line per stint sharing a slope, so quicker cars don't skew it.
+func AnalyseDegradation(laps []Lap, stints []Stint, neutralised []Interval) Degradation {
+	var analysis Degradation
+
+	// Every lap start in the session, to find cars running close behind another
+	type crossing struct {
+		at     time.Time
+		driver int
+	}
+	var crossings []crossing
+	for _, lap := range laps {
+		if !lap.Start.IsZero() {
+			crossings = append(crossings, crossing{lap.Start, lap.DriverNumber})
+		}
+	}
+	sort.Slice(crossings, func(i, j int) bool { return crossings[i].at.Before(crossings[j].at) })
+	inTraffic := func(lap Lap) bool {
+		i := sort.Search(len(crossings), func(i int) bool {
+			return !crossings[i].at.Before(lap.Start.Add(-trafficGap))
+		})
+		for ; i < len(crossings) && crossings[i].at.Before(lap.Start); i++ {
+			if crossings[i].driver != lap.DriverNumber {
+				return true
+			}
+		}
+		return false
+	}
+
+	lastStint := make(map[int]int)
+	for _, stint := range stints {
+		lastStint[stint.DriverNumber] = max(lastStint[stint.DriverNumber], stint.Number)
+	}
+
+	for _, stint := range stints {
+		var candidates []Lap
+		for _, lap := range laps {
+			if lap.DriverNumber != stint.DriverNumber || lap.Number < stint.LapStart ||
+				(stint.LapEnd > 0 && lap.Number > stint.LapEnd) {
+				continue
+			}
+			inLap := lap.Number == stint.LapEnd && stint.Number < lastStint[stint.DriverNumber]
+			switch {
+			case lap.Duration <= 0 || lap.Start.IsZero():
+				// Untimed laps have nothing to fit
+			case lap.Number == 1:
+				analysis.Exclusions.FirstLap++
+			case lap.PitOut || inLap || (stint.Number > 1 && lap.Number == stint.LapStart):
+				analysis.Exclusions.PitInOut++
+			case !IsGreenFlagLap(lap, neutralised):
+				analysis.Exclusions.Neutralised++
+			case inTraffic(lap):
+				analysis.Exclusions.Traffic++
+			default:
+				candidates = append(candidates, lap)
+			}
+		}
+		if len(candidates) < DegradationMinLaps {
+			continue
+		}
+
+		times := make([]float64, len(candidates))
+		for i, lap := range candidates {
+			times[i] = lap.Duration
+		}
+		limit := median(times) * longRunSpread
+		var clean []Lap
+		for _, lap := range candidates {
+			if lap.Duration > limit {
+				analysis.Exclusions.Slow++
+				continue
+			}
+			clean = append(clean, lap)
+		}
+		if len(clean) < DegradationMinLaps {
+			continue
+		}
+
+		analysis.Stints = append(analysis.Stints, StintDegradation{
+			Stint: stint,
+			Laps:  clean,
+			Fit:   fitDegradation([]Stint{stint}, [][]Lap{clean}),
+		})
+	}
+
+	byCompound := make(map[string][]StintDegradation)
+	var compounds []string
+	for _, stint := range analysis.Stints {
+		compound := strings.ToUpper(stint.Stint.Compound)
+		if _, ok := byCompound[compound]; !ok {
+			compounds = append(compounds, compound)
+		}
+		byCompound[compound] = append(byCompound[compound], stint)
+	}
+	for _, compound := range compounds {
+		group := byCompound[compound]
+		groupStints := make([]Stint, len(group))
+		groupLaps := make([][]Lap, len(group))
+		drivers := make(map[int]bool)
+		for i, stint := range group {
+			groupStints[i], groupLaps[i] = stint.Stint, stint.Laps
+			drivers[stint.Stint.DriverNumber] = true
+		}
+		analysis.Compounds = append(analysis.Compounds, CompoundDegradation{
+			Compound: compound,
+			Drivers:  len(drivers),
+			Fit:      fitDegradation(groupStints, groupLaps),
+		})
+	}
+	sort.Slice(analysis.Compounds, func(i, j int) bool {
+		return CompoundOrder(analysis.Compounds[i].Compound) < CompoundOrder(analysis.Compounds[j].Compound)
+	})
+	return analysis
+}
+
+// TyreAgeAt returns how many laps the set of tyres had done at the start of lap
+func (s Stint) TyreAgeAt(lap int) int {
+	return s.TyreAge + lap - s.LapStart
+}
+
+// fitDegradation fits lap time against tyre age by least squares, with a
+// common slope and a separate intercept for each stint
+func fitDegradation(stints []Stint, laps [][]Lap) DegradationFit {
+	var sxx, sxy, syy float64
+	n := 0
+	for i, stint := range stints {
+		if len(laps[i]) == 0 {
+			continue
+		}
+		var meanX, meanY float64
+		for _, lap := range laps[i] {
+			meanX += float64(stint.TyreAgeAt(lap.Number))
+			meanY += lap.Duration
+		}
+		meanX /= float64(len(laps[i]))
+		meanY /= float64(len(laps[i]))
+		for _, lap := range laps[i] {
+			dx := float64(stint.TyreAgeAt(lap.Number)) - meanX
+			dy := lap.Duration - meanY
+			sxx += dx * dx
+			sxy += dx * dy
+			syy += dy * dy
+		}
+		n += len(laps[i])
+	}
+
+	fit := DegradationFit{Laps: n, Stints: len(stints)}
+	if sxx == 0 {
+		return fit
+	}
+	slope := sxy / sxx
+	fit.Slope = slope + FuelEffect
+
+	// One degree of freedom goes on the slope and one on each intercept
+	df := n - len(stints) - 1
+	if df > 0 {
+		residual := math.Max(syy-slope*sxy, 0) / float64(df)
+		fit.Interval = studentT975(df) * math.Sqrt(residual/sxx)
+	}
+	return fit
+}
+
+// studentT975 returns the two-sided 95% critical value of Student's t
+// distribution with df degrees of freedom
+func studentT975(df int) float64 {
+	table := []float64{
+		12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+		2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+	}
+	switch {
+	case df <= len(table):
+		return table[df-1]
+	case df <= 40:
+		return 2.021
+	case df <= 60:
+		return 2.000
+	case df <= 120:
+		return 1.980
+	}
+	return 1.960
+}
+
+// CompoundOrder sorts compounds from softest to wettest
+func CompoundOrder(compound string) int {
+	switch strings.ToUpper(compound) {
+	case "SOFT":
+		return 0
+	case "MEDIUM":
+		return 1
+	case "HARD":
+		return 2
+	case "INTERMEDIATE":
+		return 3
+	case "WET":
+		return 4
+	}
+	return 5
+}
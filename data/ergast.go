@@ -0,0 +1,730 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JolpicaBaseURL is the Ergast-compatible API hosted by Jolpica
+const JolpicaBaseURL = "https://api.jolpi.ca/ergast/f1"
+
+// FirstErgastSeason is the first world championship season
+const FirstErgastSeason = 1950
+
+// ergastPageSize is the largest page the Jolpica API will return
+const ergastPageSize = 100
+
+// Ergast API response structures - the API wraps everything in MRData and
+// encodes numbers as strings
+type ergastResponse struct {
+	MRData ergastMRData `json:"MRData"`
+}
+
+type ergastMRData struct {
+	Limit          string               `json:"limit"`
+	Offset         string               `json:"offset"`
+	Total          string               `json:"total"`
+	RaceTable      ergastRaceTable      `json:"RaceTable"`
+	DriverTable    ergastDriverTable    `json:"DriverTable"`
+	StandingsTable ergastStandingsTable `json:"StandingsTable"`
+}
+
+type ergastRaceTable struct {
+	Season string       `json:"season"`
+	Races  []ErgastRace `json:"Races"`
+}
+
+type ergastDriverTable struct {
+	Drivers []ErgastDriver `json:"Drivers"`
+}
+
+type ergastStandingsTable struct {
+	StandingsLists []ErgastStandingsList `json:"StandingsLists"`
+}
+
+type ErgastRace struct {
+	Season            string                   `json:"season"`
+	Round             string                   `json:"round"`
+	RaceName          string                   `json:"raceName"`
+	Circuit           ErgastCircuit            `json:"Circuit"`
+	Date              string                   `json:"date"`
+	Time              string                   `json:"time"`
+	Sprint            *ErgastSessionTime       `json:"Sprint"`
+	Qualifying        *ErgastSessionTime       `json:"Qualifying"`
+	Results           []ErgastResult           `json:"Results"`
+	SprintResults     []ErgastResult           `json:"SprintResults"`
+	QualifyingResults []ErgastQualifyingResult `json:"QualifyingResults"`
+}
+
+type ErgastSessionTime struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
+type ErgastCircuit struct {
+	CircuitID   string `json:"circuitId"`
+	CircuitName string `json:"circuitName"`
+	Location    struct {
+		Locality string `json:"locality"`
+		Country  string `json:"country"`
+	} `json:"Location"`
+}
+
+type ErgastDriver struct {
+	DriverID        string `json:"driverId"`
+	PermanentNumber string `json:"permanentNumber"`
+	Code            string `json:"code"`
+	GivenName       string `json:"givenName"`
+	FamilyName      string `json:"familyName"`
+	DateOfBirth     string `json:"dateOfBirth"`
+	Nationality     string `json:"nationality"`
+}
+
+type ErgastConstructor struct {
+	ConstructorID string `json:"constructorId"`
+	Name          string `json:"name"`
+	Nationality   string `json:"nationality"`
+}
+
+type ErgastResult struct {
+	Number       string            `json:"number"`
+	Position     string            `json:"position"`
+	PositionText string            `json:"positionText"`
+	Points       string            `json:"points"`
+	Driver       ErgastDriver      `json:"Driver"`
+	Constructor  ErgastConstructor `json:"Constructor"`
+	Grid         string            `json:"grid"`
+	Laps         string            `json:"laps"`
+	Status       string            `json:"status"`
+	Time         *struct {
+		Millis string `json:"millis"`
+		Time   string `json:"time"`
+	} `json:"Time"`
+	FastestLap *struct {
+		Rank string `json:"rank"`
+		Lap  string `json:"lap"`
+		Time struct {
+			Time string `json:"time"`
+		} `json:"Time"`
+	} `json:"FastestLap"`
+}
+
+type ErgastQualifyingResult struct {
+	Number      string            `json:"number"`
+	Position    string            `json:"position"`
+	Driver      ErgastDriver      `json:"Driver"`
+	Constructor ErgastConstructor `json:"Constructor"`
+	Q1          string            `json:"Q1"`
+	Q2          string            `json:"Q2"`
+	Q3          string            `json:"Q3"`
+}
+
+type ErgastStandingsList struct {
+	Season               string                      `json:"season"`
+	Round                string                      `json:"round"`
+	DriverStandings      []ErgastDriverStanding      `json:"DriverStandings"`
+	ConstructorStandings []ErgastConstructorStanding `json:"ConstructorStandings"`
+}
+
+type ErgastDriverStanding struct {
+	Position     string              `json:"position"`
+	PositionText string              `json:"positionText"`
+	Points       string              `json:"points"`
+	Wins         string              `json:"wins"`
+	Driver       ErgastDriver        `json:"Driver"`
+	Constructors []ErgastConstructor `json:"Constructors"`
+}
+
+type ErgastConstructorStanding struct {
+	Position     string            `json:"position"`
+	PositionText string            `json:"positionText"`
+	Points       string            `json:"points"`
+	Wins         string            `json:"wins"`
+	Constructor  ErgastConstructor `json:"Constructor"`
+}
+
+// FullName returns the driver's name in the same "Given Family" form OpenF1 uses
+func (d ErgastDriver) FullName() string {
+	return d.GivenName + " " + d.FamilyName
+}
+
+// ErgastSource reads historical and current seasons from an Ergast-compatible API
+type ErgastSource struct {
+	client *APIClient
+}
+
+// NewJolpicaClient returns an API client configured for the Jolpica API
+func NewJolpicaClient() *APIClient {
+	client := NewAPIClient()
+	client.BaseURL = JolpicaBaseURL
+	client.Limiter = NewJolpicaRateLimiter()
+	client.CacheTTL = ergastCacheTTL
+	return client
+}
+
+// NewJolpicaRateLimiter matches Jolpica's published limits: a burst of 4
+// requests per second and 500 requests per hour.
+func NewJolpicaRateLimiter() *RateLimiter {
+	return NewRateLimiter(
+		NewTokenBucket(4, time.Second),
+		NewTokenBucket(500, time.Hour),
+	)
+}
+
+// NewErgastSource wraps a client pointed at an Ergast-compatible API
+func NewErgastSource(client *APIClient) *ErgastSource {
+	return &ErgastSource{client: client}
+}
+
+// ergastCacheTTL keeps finished seasons forever; the current season follows
+// the usual schedule and live lifetimes
+func ergastCacheTTL(endpoint string) time.Duration {
+	seasonPart, rest, _ := strings.Cut(endpoint, "/")
+	seasonPart = strings.TrimSuffix(strings.SplitN(seasonPart, "?", 2)[0], ".json")
+
+	if season, err := strconv.Atoi(seasonPart); err == nil && season < CurrentSeason() {
+		return CacheForever
+	}
+	if rest == "" {
+		return ScheduleTTL
+	}
+	return LiveTTL
+}
+
+// Client returns the wrapped API client
+func (s *ErgastSource) Client() *APIClient {
+	return s.client
+}
+
+func (s *ErgastSource) Cache() *ResponseCache {
+	return s.client.Cache
+}
+
+func (s *ErgastSource) SetCache(cache *ResponseCache) {
+	s.client.Cache = cache
+}
+
+func (s *ErgastSource) Name() string {
+	return "Jolpica F1 API (Ergast)"
+}
+
+func (s *ErgastSource) FirstSeason() int {
+	return FirstErgastSeason
+}
+
+func (s *ErgastSource) Ping(ctx context.Context) error {
+	_, err := s.client.fetchOnce(ctx, fmt.Sprintf("%s/%s", s.client.BaseURL, "current.json?limit=1"))
+	return err
+}
+
+// get fetches a single page of an Ergast endpoint
+func (s *ErgastSource) get(ctx context.Context, path string, offset int) (ergastMRData, error) {
+	endpoint := fmt.Sprintf("%s?limit=%d&offset=%d", path, ergastPageSize, offset)
+	data, err := s.client.makeRequest(ctx, endpoint)
+	if err != nil {
+		return ergastMRData{}, err
+	}
+
+	var response ergastResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return ergastMRData{}, fmt.Errorf("failed to parse %s response: %w", path, err)
+	}
+
+	return response.MRData, nil
+}
+
+// getAll walks every page of path. collect returns how many rows the page
+// contributed, so paging stops even if the API misreports its total.
+func (s *ErgastSource) getAll(ctx context.Context, path string, collect func(ergastMRData) int) error {
+	for offset := 0; ; {
+		page, err := s.get(ctx, path, offset)
+		if err != nil {
+			return err
+		}
+
+		rows := collect(page)
+		offset += rows
+		total, _ := strconv.Atoi(page.Total)
+		if rows == 0 || offset >= total {
+			return nil
+		}
+	}
+}
+
+// races returns the season calendar
+func (s *ErgastSource) races(ctx context.Context, season int) ([]ErgastRace, error) {
+	var races []ErgastRace
+	err := s.getAll(ctx, fmt.Sprintf("%d.json", season), func(page ergastMRData) int {
+		races = append(races, page.RaceTable.Races...)
+		return len(page.RaceTable.Races)
+	})
+	return races, err
+}
+
+// driverStandings returns the season's official driver standings table
+func (s *ErgastSource) driverStandings(ctx context.Context, season int) ([]ErgastDriverStanding, error) {
+	var standings []ErgastDriverStanding
+	err := s.getAll(ctx, fmt.Sprintf("%d/driverStandings.json", season), func(page ergastMRData) int {
+		rows := 0
+		for _, list := range page.StandingsTable.StandingsLists {
+			standings = append(standings, list.DriverStandings...)
+			rows += len(list.DriverStandings)
+		}
+		return rows
+	})
+	return standings, err
+}
+
+// carNumbers maps each driver to the number they last raced with in the
+// season. Permanent numbers only date from 2014 and the champion may run #1
+// instead, so the results are the only reliable record.
+func (s *ErgastSource) carNumbers(ctx context.Context, season int) (map[string]int, error) {
+	numbers := make(map[string]int)
+	err := s.getAll(ctx, fmt.Sprintf("%d/results.json", season), func(page ergastMRData) int {
+		n := 0
+		for _, race := range page.RaceTable.Races {
+			for _, row := range race.Results {
+				if number, err := strconv.Atoi(row.Number); err == nil {
+					numbers[row.Driver.DriverID] = number
+				}
+			}
+			n += len(race.Results)
+		}
+		return n
+	})
+	return numbers, err
+}
+
+// carNumber returns the number a driver raced with, falling back to their
+// permanent number before they have started a race
+func carNumber(driver ErgastDriver, numbers map[string]int) int {
+	if number, ok := numbers[driver.DriverID]; ok {
+		return number
+	}
+	number, _ := strconv.Atoi(driver.PermanentNumber)
+	return number
+}
+
+func (s *ErgastSource) Drivers(ctx context.Context, season int) ([]Driver, error) {
+	standings, err := s.driverStandings(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := s.carNumbers(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []Driver{}
+	for i, standing := range standings {
+		team := ""
+		if n := len(standing.Constructors); n > 0 {
+			team = standing.Constructors[n-1].Name
+		}
+
+		result = append(result, Driver{
+			ID:       i + 1,
+			Name:     standing.Driver.FullName(),
+			LastName: standing.Driver.FamilyName,
+			Acronym:  standing.Driver.Code,
+			Number:   carNumber(standing.Driver, numbers),
+			Team:     team,
+			Country:  standing.Driver.Nationality,
+		})
+	}
+
+	if len(result) > 0 {
+		return result, nil
+	}
+
+	// Before the first race there are no standings yet, so fall back to the entry list
+	err = s.getAll(ctx, fmt.Sprintf("%d/drivers.json", season), func(page ergastMRData) int {
+		for _, driver := range page.DriverTable.Drivers {
+			result = append(result, Driver{
+				ID:       len(result) + 1,
+				Name:     driver.FullName(),
+				LastName: driver.FamilyName,
+				Acronym:  driver.Code,
+				Number:   carNumber(driver, numbers),
+				Country:  driver.Nationality,
+			})
+		}
+		return len(page.DriverTable.Drivers)
+	})
+	return result, err
+}
+
+func (s *ErgastSource) Sessions(ctx context.Context, season int) ([]Session, error) {
+	races, err := s.races(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	for _, race := range races {
+		round, _ := strconv.Atoi(race.Round)
+		base := Session{
+			MeetingKey:  season*100 + round,
+			MeetingName: race.RaceName,
+			Location:    race.Circuit.Location.Locality,
+			Country:     race.Circuit.Location.Country,
+			Circuit:     race.Circuit.CircuitName,
+			Round:       round,
+			Year:        season,
+		}
+
+		// Sprints happen before the race, so keep calendar order
+		if race.Sprint != nil {
+			sprint := base
+			sprint.Key = ergastSessionKey(season, round, "Sprint")
+			sprint.Name, sprint.Type = "Sprint", "Race"
+			sprint.DateStart = parseErgastTime(race.Sprint.Date, race.Sprint.Time)
+			sprint.DateEnd = sprint.DateStart.Add(time.Hour)
+			sessions = append(sessions, sprint)
+		}
+
+		grandPrix := base
+		grandPrix.Key = ergastSessionKey(season, round, "Race")
+		grandPrix.Name, grandPrix.Type = "Race", "Race"
+		grandPrix.DateStart = parseErgastTime(race.Date, race.Time)
+		grandPrix.DateEnd = grandPrix.DateStart.Add(2 * time.Hour)
+		sessions = append(sessions, grandPrix)
+	}
+
+	return sessions, nil
+}
+
+// ergastSessionKey invents a stable session key, since Ergast has none
+func ergastSessionKey(season, round int, name string) int {
+	key := season*1000 + round*10
+	switch name {
+	case "Sprint":
+		key++
+	case "Qualifying":
+		key += 2
+	}
+	return key
+}
+
+// parseErgastTime combines Ergast's separate date and time fields. Older
+// races have no start time, so they default to midnight UTC.
+func parseErgastTime(date, clock string) time.Time {
+	if clock == "" {
+		clock = "00:00:00Z"
+	}
+	if t, err := time.Parse(time.RFC3339, date+"T"+clock); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02", date)
+	return t
+}
+
+// results fetches the raw result rows of a race or sprint
+func (s *ErgastSource) results(ctx context.Context, session Session) ([]ErgastResult, error) {
+	endpoint := "results.json"
+	if session.Name == "Sprint" {
+		endpoint = "sprint.json"
+	}
+
+	var rows []ErgastResult
+	err := s.getAll(ctx, fmt.Sprintf("%d/%d/%s", session.Year, session.Round, endpoint), func(page ergastMRData) int {
+		n := 0
+		for _, race := range page.RaceTable.Races {
+			rows = append(rows, race.Results...)
+			rows = append(rows, race.SprintResults...)
+			n += len(race.Results) + len(race.SprintResults)
+		}
+		return n
+	})
+	return rows, err
+}
+
+func (s *ErgastSource) Classification(ctx context.Context, session Session) ([]SessionResult, error) {
+	rows, err := s.results(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SessionResult, len(rows))
+	for i, row := range rows {
+		number, _ := strconv.Atoi(row.Number)
+		position, _ := strconv.Atoi(row.Position)
+		laps, _ := strconv.Atoi(row.Laps)
+		results[i] = SessionResult{
+			DriverNumber: number,
+			Position:     position,
+			Driver:       row.Driver.FullName(),
+			Team:         row.Constructor.Name,
+			FastestLap:   row.FastestLap != nil && row.FastestLap.Rank == "1",
+			Status:       row.Status,
+			Laps:         laps,
+		}
+
+		// positionText is the position for classified finishers and a letter otherwise
+		switch row.PositionText {
+		case "R", "N":
+			results[i].DNF = true
+		case "W", "F":
+			results[i].DNS = true
+		case "D", "E":
+			results[i].DSQ = true
+		}
+
+		// The winner's time is the race duration; everyone else's is a gap
+		if row.Time != nil && position > 1 {
+			results[i].Gap = row.Time.Time
+			if gap, err := strconv.ParseFloat(strings.TrimPrefix(row.Time.Time, "+"), 64); err == nil {
+				results[i].Gap = fmt.Sprintf("+%.3fs", gap)
+				results[i].GapSeconds = gap
+			}
+		} else if position > 1 && strings.HasPrefix(row.Status, "+") {
+			results[i].Gap = row.Status
+		}
+	}
+
+	return results, nil
+}
+
+// FastestLap returns the fastest lap of a race or sprint, or nil for races
+// from before fastest laps were recorded (2004)
+func (s *ErgastSource) FastestLap(ctx context.Context, session Session) (*FastestLap, error) {
+	rows, err := s.results(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.FastestLap == nil || row.FastestLap.Rank != "1" {
+			continue
+		}
+		number, _ := strconv.Atoi(row.Number)
+		lap, _ := strconv.Atoi(row.FastestLap.Lap)
+		t, _ := ParseLapTime(row.FastestLap.Time.Time)
+		return &FastestLap{DriverNumber: number, Driver: row.Driver.FullName(), Lap: lap, Time: t}, nil
+	}
+	return nil, nil
+}
+
+// WeekendSessions returns each round's qualifying session. Ergast has no
+// practice or sprint qualifying results, so other types come back empty.
+func (s *ErgastSource) WeekendSessions(ctx context.Context, season int, sessionType string) ([]Session, error) {
+	if sessionType != "Qualifying" {
+		return nil, nil
+	}
+
+	races, err := s.races(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(races))
+	for _, race := range races {
+		round, _ := strconv.Atoi(race.Round)
+		session := Session{
+			Key:         ergastSessionKey(season, round, "Qualifying"),
+			Name:        "Qualifying",
+			Type:        "Qualifying",
+			MeetingKey:  season*100 + round,
+			MeetingName: race.RaceName,
+			Location:    race.Circuit.Location.Locality,
+			Country:     race.Circuit.Location.Country,
+			Circuit:     race.Circuit.CircuitName,
+			Round:       round,
+			Year:        season,
+		}
+		// Older schedules don't list qualifying; it was the day before the race
+		if race.Qualifying != nil {
+			session.DateStart = parseErgastTime(race.Qualifying.Date, race.Qualifying.Time)
+		} else {
+			session.DateStart = parseErgastTime(race.Date, race.Time).AddDate(0, 0, -1)
+		}
+		session.DateEnd = session.DateStart.Add(time.Hour)
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// Qualifying returns the qualifying classification for a round
+func (s *ErgastSource) Qualifying(ctx context.Context, session Session) ([]QualifyingResult, error) {
+	var rows []ErgastQualifyingResult
+	err := s.getAll(ctx, fmt.Sprintf("%d/%d/qualifying.json", session.Year, session.Round), func(page ergastMRData) int {
+		n := 0
+		for _, race := range page.RaceTable.Races {
+			rows = append(rows, race.QualifyingResults...)
+			n += len(race.QualifyingResults)
+		}
+		return n
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]QualifyingResult, len(rows))
+	for i, row := range rows {
+		number, _ := strconv.Atoi(row.Number)
+		position, _ := strconv.Atoi(row.Position)
+		results[i] = QualifyingResult{
+			DriverNumber: number,
+			Position:     position,
+			Driver:       row.Driver.FullName(),
+			Team:         row.Constructor.Name,
+		}
+		for segment, text := range []string{row.Q1, row.Q2, row.Q3} {
+			if t, ok := ParseLapTime(text); ok {
+				results[i].Times[segment] = t
+			}
+		}
+	}
+	setSegments(results)
+	return results, nil
+}
+
+func (s *ErgastSource) Schedule(ctx context.Context, season int) ([]Race, error) {
+	races, err := s.races(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	// Winners, pole sitters and fastest laps for the whole season come from
+	// three requests; missing data (e.g. no qualifying records for early
+	// seasons) leaves the column empty
+	winners, err := s.byRound(ctx, fmt.Sprintf("%d/results/1.json", season), func(race ErgastRace) string {
+		if len(race.Results) > 0 {
+			return race.Results[0].Driver.FullName()
+		}
+		return ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	poles, err := s.byRound(ctx, fmt.Sprintf("%d/qualifying/1.json", season), func(race ErgastRace) string {
+		if len(race.QualifyingResults) > 0 {
+			return race.QualifyingResults[0].Driver.FullName()
+		}
+		return ""
+	})
+	if err != nil {
+		return nil, err
+	}
+	fastestLaps, err := s.byRound(ctx, fmt.Sprintf("%d/fastest/1/results.json", season), func(race ErgastRace) string {
+		if len(race.Results) == 0 || race.Results[0].FastestLap == nil {
+			return ""
+		}
+		row := race.Results[0]
+		t, _ := ParseLapTime(row.FastestLap.Time.Time)
+		lap, _ := strconv.Atoi(row.FastestLap.Lap)
+		return FastestLap{Driver: row.Driver.FullName(), Lap: lap, Time: t}.String()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Race, len(races))
+	for i, race := range races {
+		round, _ := strconv.Atoi(race.Round)
+		date := parseErgastTime(race.Date, race.Time)
+		status := raceStatus(Session{Name: "Race", DateStart: date}.State())
+
+		result[i] = Race{
+			Round:        round,
+			Name:         race.RaceName,
+			Circuit:      race.Circuit.CircuitName,
+			Country:      race.Circuit.Location.Country,
+			Date:         date,
+			Time:         date.Format("15:04"),
+			Status:       status,
+			Winner:       winners[round],
+			PolePosition: poles[round],
+			FastestLap:   fastestLaps[round],
+		}
+	}
+
+	return result, nil
+}
+
+// byRound maps each round of a season-wide query to a single value. A query
+// the API has no data for gives an empty map.
+func (s *ErgastSource) byRound(ctx context.Context, path string, value func(ErgastRace) string) (map[int]string, error) {
+	values := make(map[int]string)
+	err := s.getAll(ctx, path, func(page ergastMRData) int {
+		for _, race := range page.RaceTable.Races {
+			round, _ := strconv.Atoi(race.Round)
+			values[round] = value(race)
+		}
+		return len(page.RaceTable.Races)
+	})
+	if err != nil && !isNoData(err) {
+		return nil, err
+	}
+	return values, nil
+}
+
+// DriverStandings returns the official driver standings published by the API
+func (s *ErgastSource) DriverStandings(ctx context.Context, season int) ([]StandingEntry, error) {
+	rows, err := s.driverStandings(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]StandingEntry, len(rows))
+	for i, row := range rows {
+		team := ""
+		if n := len(row.Constructors); n > 0 {
+			team = row.Constructors[n-1].Name
+		}
+		standings[i] = ergastStandingEntry(i, row.Position, row.Points, row.Wins)
+		standings[i].Driver = row.Driver.FullName()
+		standings[i].Team = team
+	}
+
+	fillStandingGaps(standings)
+	return standings, nil
+}
+
+// ConstructorStandings returns the official constructor standings published by the API
+func (s *ErgastSource) ConstructorStandings(ctx context.Context, season int) ([]StandingEntry, error) {
+	var rows []ErgastConstructorStanding
+	err := s.getAll(ctx, fmt.Sprintf("%d/constructorStandings.json", season), func(page ergastMRData) int {
+		n := 0
+		for _, list := range page.StandingsTable.StandingsLists {
+			rows = append(rows, list.ConstructorStandings...)
+			n += len(list.ConstructorStandings)
+		}
+		return n
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]StandingEntry, len(rows))
+	for i, row := range rows {
+		standings[i] = ergastStandingEntry(i, row.Position, row.Points, row.Wins)
+		standings[i].Driver = row.Constructor.Name // Using Driver field for team name
+		standings[i].Team = row.Constructor.Nationality
+	}
+
+	fillStandingGaps(standings)
+	return standings, nil
+}
+
+// ergastStandingEntry converts the numeric string fields shared by both
+// standings tables. Excluded entries (e.g. Schumacher in 1997) have no
+// position, so they keep their place in the list.
+func ergastStandingEntry(index int, position, points, wins string) StandingEntry {
+	pos, err := strconv.Atoi(position)
+	if err != nil {
+		pos = index + 1
+	}
+	pts, _ := strconv.ParseFloat(points, 64)
+	w, _ := strconv.Atoi(wins)
+
+	return StandingEntry{
+		Position: pos,
+		Points:   pts,
+		Wins:     w,
+	}
+}
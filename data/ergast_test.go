@@ -0,0 +1,199 @@
+package data
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newJolpicaFixture serves the responses recorded under testdata/jolpica,
+// laid out by request path
+func newJolpicaFixture(t *testing.T) *ErgastSource {
+	t.Helper()
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata/jolpica")))
+	t.Cleanup(srv.Close)
+	return NewErgastSource(newTestClient(srv))
+}
+
+func TestErgastSchedule(t *testing.T) {
+	races, err := newJolpicaFixture(t).Schedule(context.Background(), 2023)
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if len(races) != 2 {
+		t.Fatalf("got %d races, want 2", len(races))
+	}
+
+	bahrain := races[0]
+	want := Race{
+		Round:        1,
+		Name:         "Bahrain Grand Prix",
+		Circuit:      "Bahrain International Circuit",
+		Country:      "Bahrain",
+		Date:         time.Date(2023, 3, 5, 15, 0, 0, 0, time.UTC),
+		Time:         "15:00",
+		Status:       "completed",
+		Winner:       "Max Verstappen",
+		PolePosition: "Max Verstappen",
+		FastestLap:   "Guanyu Zhou 1:33.996 (lap 56)",
+	}
+	if !bahrain.Date.Equal(want.Date) {
+		t.Errorf("Date = %v, want %v", bahrain.Date, want.Date)
+	}
+	bahrain.Date = want.Date
+	if bahrain != want {
+		t.Errorf("round 1 = %+v\nwant %+v", bahrain, want)
+	}
+
+	saudi := races[1]
+	if saudi.Round != 2 || saudi.Winner != "Sergio Pérez" || saudi.PolePosition != "Sergio Pérez" ||
+		saudi.FastestLap != "Max Verstappen 1:31.906 (lap 50)" {
+		t.Errorf("round 2 = %+v", saudi)
+	}
+}
+
+func TestErgastScheduleColumnErrors(t *testing.T) {
+	// status answers the season's qualifying query; everything else is recorded
+	serve := func(status int) *ErgastSource {
+		files := http.FileServer(http.Dir("testdata/jolpica"))
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/qualifying/") {
+				http.Error(w, http.StatusText(status), status)
+				return
+			}
+			files.ServeHTTP(w, r)
+		}))
+		t.Cleanup(srv.Close)
+		return NewErgastSource(newTestClient(srv))
+	}
+
+	races, err := serve(http.StatusNotFound).Schedule(context.Background(), 2023)
+	if err != nil {
+		t.Fatalf("Schedule without qualifying data: %v", err)
+	}
+	if races[0].PolePosition != "" || races[0].Winner != "Max Verstappen" {
+		t.Errorf("round 1 = %+v, want a winner and no pole sitter", races[0])
+	}
+
+	if _, err := serve(http.StatusInternalServerError).Schedule(context.Background(), 2023); err == nil {
+		t.Error("Schedule hid a failed qualifying query")
+	}
+}
+
+func TestErgastClassification(t *testing.T) {
+	source := newJolpicaFixture(t)
+	session := Session{Name: "Race", Type: "Race", Round: 1, Year: 2023}
+	results, err := source.Classification(context.Background(), session)
+	if err != nil {
+		t.Fatalf("Classification: %v", err)
+	}
+
+	want := []SessionResult{
+		{DriverNumber: 1, Position: 1, Driver: "Max Verstappen", Team: "Red Bull", Status: "Finished", Laps: 57},
+		{DriverNumber: 11, Position: 2, Driver: "Sergio Pérez", Team: "Red Bull", Status: "Finished", Laps: 57,
+			Gap: "+11.987s", GapSeconds: 11.987},
+		{DriverNumber: 14, Position: 3, Driver: "Fernando Alonso", Team: "Aston Martin", Status: "Finished", Laps: 57,
+			Gap: "+38.637s", GapSeconds: 38.637},
+		// Gaps over a minute keep Ergast's own formatting
+		{DriverNumber: 77, Position: 8, Driver: "Valtteri Bottas", Team: "Alfa Romeo", Status: "Finished", Laps: 57,
+			Gap: "+1:12.647"},
+		{DriverNumber: 24, Position: 16, Driver: "Guanyu Zhou", Team: "Alfa Romeo", Status: "+1 Lap", Laps: 56,
+			Gap: "+1 Lap", FastestLap: true},
+		{DriverNumber: 16, Position: 18, Driver: "Charles Leclerc", Team: "Ferrari", Status: "Power Unit", Laps: 39,
+			DNF: true},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d = %+v\nwant %+v", i, results[i], want[i])
+		}
+	}
+
+	fastest, err := source.FastestLap(context.Background(), session)
+	if err != nil {
+		t.Fatalf("FastestLap: %v", err)
+	}
+	if fastest == nil || fastest.DriverNumber != 24 || fastest.Lap != 56 || math.Abs(fastest.Time-93.996) > 1e-9 {
+		t.Errorf("FastestLap = %+v, want Zhou's 1:33.996 on lap 56", fastest)
+	}
+}
+
+func TestErgastStandings(t *testing.T) {
+	source := newJolpicaFixture(t)
+
+	drivers, err := source.DriverStandings(context.Background(), 2023)
+	if err != nil {
+		t.Fatalf("DriverStandings: %v", err)
+	}
+	wantDrivers := []StandingEntry{
+		{Position: 1, Driver: "Max Verstappen", Team: "Red Bull", Points: 44, Wins: 1, Gap: "Leader"},
+		{Position: 2, Driver: "Sergio Pérez", Team: "Red Bull", Points: 43, Wins: 1, Gap: "-1"},
+		{Position: 3, Driver: "Fernando Alonso", Team: "Aston Martin", Points: 30, Gap: "-14"},
+	}
+	assertStandings(t, "drivers", drivers, wantDrivers)
+
+	teams, err := source.ConstructorStandings(context.Background(), 2023)
+	if err != nil {
+		t.Fatalf("ConstructorStandings: %v", err)
+	}
+	wantTeams := []StandingEntry{
+		{Position: 1, Driver: "Red Bull", Team: "Austrian", Points: 87, Wins: 2, Gap: "Leader"},
+		{Position: 2, Driver: "Aston Martin", Team: "British", Points: 38, Gap: "-49"},
+		{Position: 3, Driver: "Mercedes", Team: "German", Points: 38, Gap: "-49"},
+	}
+	assertStandings(t, "constructors", teams, wantTeams)
+}
+
+func assertStandings(t *testing.T, table string, got, want []StandingEntry) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %d entries, want %d", table, len(got), len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Position != w.Position || g.Driver != w.Driver || g.Team != w.Team ||
+			g.Points != w.Points || g.Wins != w.Wins || g.Gap != w.Gap {
+			t.Errorf("%s %d = %+v\nwant %+v", table, i, g, w)
+		}
+	}
+}
+
+func TestErgastDriversUseCarNumbers(t *testing.T) {
+	drivers, err := newJolpicaFixture(t).Drivers(context.Background(), 2023)
+	if err != nil {
+		t.Fatalf("Drivers: %v", err)
+	}
+
+	// Verstappen's permanent number is 33, but he raced as the champion's #1
+	want := []Driver{
+		{ID: 1, Name: "Max Verstappen", LastName: "Verstappen", Acronym: "VER", Number: 1, Team: "Red Bull", Country: "Dutch"},
+		{ID: 2, Name: "Sergio Pérez", LastName: "Pérez", Acronym: "PER", Number: 11, Team: "Red Bull", Country: "Mexican"},
+		{ID: 3, Name: "Fernando Alonso", LastName: "Alonso", Acronym: "ALO", Number: 14, Team: "Aston Martin", Country: "Spanish"},
+	}
+	if len(drivers) != len(want) {
+		t.Fatalf("got %d drivers, want %d", len(drivers), len(want))
+	}
+	for i := range want {
+		if drivers[i] != want[i] {
+			t.Errorf("driver %d = %+v\nwant %+v", i, drivers[i], want[i])
+		}
+	}
+}
+
+func TestErgastDriversBeforeFirstRace(t *testing.T) {
+	drivers, err := newJolpicaFixture(t).Drivers(context.Background(), 2026)
+	if err != nil {
+		t.Fatalf("Drivers: %v", err)
+	}
+
+	// Without results the entry list's permanent numbers are all there is
+	if len(drivers) != 2 || drivers[0].Number != 3 || drivers[1].Number != 14 || drivers[0].Name != "Max Verstappen" {
+		t.Errorf("Drivers = %+v, want Verstappen #3 and Alonso #14", drivers)
+	}
+}
@@ -1,32 +1,41 @@
 package data
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
 
 // Driver represents a Formula 1 driver
 type Driver struct {
-	ID            int    `json:"id"`
-	Name          string `json:"name"`
-	Number        int    `json:"number"`
-	Team          string `json:"team"`
-	Country       string `json:"country"`
-	Points        int    `json:"points"`
-	Wins          int    `json:"wins"`
-	Podiums       int    `json:"podiums"`
-	Championships int    `json:"championships"`
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	LastName      string  `json:"last_name"`
+	Acronym       string  `json:"acronym"`
+	Number        int     `json:"number"`
+	Team          string  `json:"team"`
+	Country       string  `json:"country"`
+	Points        float64 `json:"points"`
+	Wins          int     `json:"wins"`
+	Podiums       int     `json:"podiums"`
+	Championships int     `json:"championships"`
 }
 
 type Team struct {
 	ID            int      `json:"id"`
 	Name          string   `json:"name"`
 	Country       string   `json:"country"`
-	Points        int      `json:"points"`
+	Points        float64  `json:"points"`
 	Drivers       []string `json:"drivers"`
 	Founded       int      `json:"founded"`
 	Championships int      `json:"championships"`
 }
 
 type Race struct {
-	Round        int       `json:"round"`
+	// Round is the championship round, or 0 for events that aren't part of it
+	Round int `json:"round"`
+	// Testing marks pre-season testing and other meetings without a race
+	Testing      bool      `json:"testing,omitempty"`
 	Name         string    `json:"name"`
 	Circuit      string    `json:"circuit"`
 	Country      string    `json:"country"`
@@ -50,10 +59,150 @@ type Circuit struct {
 }
 
 type StandingEntry struct {
-	Position int    `json:"position"`
-	Driver   string `json:"driver"`
-	Team     string `json:"team"`
-	Points   int    `json:"points"`
-	Wins     int    `json:"wins"`
-	Gap      string `json:"gap"`
+	Position int     `json:"position"`
+	Driver   string  `json:"driver"`
+	Team     string  `json:"team"`
+	Points   float64 `json:"points"`
+	Wins     int     `json:"wins"`
+	Gap      string  `json:"gap"`
+	// Countback counts race finishes by position: Countback[0] is wins,
+	// Countback[1] second places, and so on
+	Countback []int `json:"countback,omitempty"`
+	// Tied marks entries that share their position after countback
+	Tied bool `json:"tied,omitempty"`
+	// TieBreak explains how the entry was ordered against the one above it on equal points
+	TieBreak string `json:"tie_break,omitempty"`
+}
+
+// PositionLabel returns the position as displayed, with "=" for shared places
+func (e StandingEntry) PositionLabel() string {
+	if e.Tied {
+		return fmt.Sprintf("=%d", e.Position)
+	}
+	return strconv.Itoa(e.Position)
+}
+
+// Session is a single on-track session (race, sprint, ...) of a meeting
+type Session struct {
+	Key         int       `json:"key"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	MeetingKey  int       `json:"meeting_key"`
+	MeetingName string    `json:"meeting_name"`
+	Location    string    `json:"location"`
+	Country     string    `json:"country"`
+	Circuit     string    `json:"circuit"`
+	Round       int       `json:"round,omitempty"`
+	Year        int       `json:"year"`
+	DateStart   time.Time `json:"date_start"`
+	DateEnd     time.Time `json:"date_end"`
+}
+
+// SessionResult is one line of a session's classification.
+// Driver and Team are filled in by sources that report them alongside results.
+type SessionResult struct {
+	DriverNumber int    `json:"driver_number"`
+	Position     int    `json:"position"`
+	Driver       string `json:"driver,omitempty"`
+	Team         string `json:"team,omitempty"`
+	// FastestLap marks the driver who set the session's fastest lap
+	FastestLap bool `json:"fastest_lap,omitempty"`
+	// Status describes how the session ended for the driver, e.g. "Finished" or "Retired"
+	Status string `json:"status,omitempty"`
+	Laps   int    `json:"laps,omitempty"`
+	// Gap is the gap to the winner as shown on timing screens ("+5.123s", "+1 LAP")
+	Gap        string  `json:"gap,omitempty"`
+	GapSeconds float64 `json:"gap_seconds,omitempty"`
+	DNF        bool    `json:"dnf,omitempty"`
+	DNS        bool    `json:"dns,omitempty"`
+	DSQ        bool    `json:"dsq,omitempty"`
+}
+
+// QualifyingResult is a driver's qualifying session: their best lap in each
+// part and where that left them on the grid
+type QualifyingResult struct {
+	DriverNumber int    `json:"driver_number"`
+	Position     int    `json:"position"`
+	Driver       string `json:"driver,omitempty"`
+	Team         string `json:"team,omitempty"`
+	// Times are the best laps in Q1, Q2 and Q3 (SQ1-SQ3 in sprint
+	// qualifying) in seconds, zero where the driver set no time
+	Times [3]float64 `json:"times"`
+	// Segment is the last part the driver took part in: 1, 2 or 3
+	Segment int `json:"segment"`
+	Laps    int `json:"laps,omitempty"`
+}
+
+// Lap is one lap of a session
+type Lap struct {
+	DriverNumber int       `json:"driver_number"`
+	Number       int       `json:"number"`
+	Start        time.Time `json:"start"`
+	// Duration is the lap time in seconds, zero when the lap wasn't timed
+	Duration float64 `json:"duration"`
+	// Sectors are the three sector times in seconds, zero where not timed
+	Sectors [3]float64 `json:"sectors"`
+	PitOut  bool       `json:"pit_out,omitempty"`
+	// Speeds in km/h at the two intermediates and the speed trap
+	SpeedI1   int `json:"speed_i1,omitempty"`
+	SpeedI2   int `json:"speed_i2,omitempty"`
+	SpeedTrap int `json:"speed_trap,omitempty"`
+}
+
+// FastestLap is the quickest lap of a session
+type FastestLap struct {
+	DriverNumber int     `json:"driver_number"`
+	Driver       string  `json:"driver,omitempty"`
+	Lap          int     `json:"lap"`
+	Time         float64 `json:"time"`
+}
+
+// Stint is a run on one set of tyres
+type Stint struct {
+	DriverNumber int `json:"driver_number"`
+	Number       int `json:"number"`
+	// Compound is SOFT, MEDIUM, HARD, INTERMEDIATE or WET
+	Compound string `json:"compound"`
+	LapStart int    `json:"lap_start"`
+	LapEnd   int    `json:"lap_end"`
+	// TyreAge is how many laps the set had done before the stint
+	TyreAge int `json:"tyre_age"`
+}
+
+// WeatherSample is a weather reading at the track
+type WeatherSample struct {
+	Time time.Time `json:"time"`
+	// Temperatures in °C, humidity in percent, pressure in mbar
+	AirTemp   float64 `json:"air_temp"`
+	TrackTemp float64 `json:"track_temp"`
+	Humidity  float64 `json:"humidity"`
+	Pressure  float64 `json:"pressure,omitempty"`
+	Rain      bool    `json:"rain,omitempty"`
+	// WindSpeed is in m/s; WindDirection is in degrees, 0 being north
+	WindSpeed     float64 `json:"wind_speed"`
+	WindDirection int     `json:"wind_direction"`
+}
+
+// PitStop is a driver's trip through the pit lane
+type PitStop struct {
+	DriverNumber int       `json:"driver_number"`
+	Lap          int       `json:"lap"`
+	Date         time.Time `json:"date"`
+	// LaneTime is pit entry to pit exit in seconds, StopTime the part spent
+	// stationary; either is zero when it wasn't recorded
+	LaneTime float64 `json:"lane_time,omitempty"`
+	StopTime float64 `json:"stop_time,omitempty"`
+}
+
+// RaceControlMessage is a flag, safety car or other notice from race control
+type RaceControlMessage struct {
+	Date     time.Time `json:"date"`
+	Category string    `json:"category"`
+	Flag     string    `json:"flag,omitempty"`
+	// Scope is "Track", "Sector" or "Driver"
+	Scope        string `json:"scope,omitempty"`
+	Sector       int    `json:"sector,omitempty"`
+	DriverNumber int    `json:"driver_number,omitempty"`
+	Lap          int    `json:"lap,omitempty"`
+	Message      string `json:"message"`
 }
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultFetchWorkers bounds how many sessions are fetched at the same time
+const DefaultFetchWorkers = 4
+
+// SessionFetch pairs a session with the classification fetched for it
+type SessionFetch struct {
+	Session Session
+	Results []SessionResult
+	Err     error
+}
+
+// FetchClassifications fetches the classification of every session using a
+// bounded worker pool. The returned slice has the same order as sessions,
+// whatever order the requests complete in.
+func (ds *DataService) FetchClassifications(sessions []Session) []SessionFetch {
+	return ds.FetchClassificationsContext(context.Background(), sessions)
+}
+
+// FetchClassificationsContext is like FetchClassifications but takes a context.
+// Sessions not yet started when ctx is cancelled report ctx's error.
+func (ds *DataService) FetchClassificationsContext(ctx context.Context, sessions []Session) []SessionFetch {
+	results, errs := fetchEach(ctx, ds.workers(), sessions, ds.classification)
+	fetches := make([]SessionFetch, len(sessions))
+	for i, session := range sessions {
+		fetches[i] = SessionFetch{Session: session, Results: results[i], Err: errs[i]}
+	}
+	return fetches
+}
+
+// workers returns how many sessions may be fetched at the same time
+func (ds *DataService) workers() int {
+	if ds.Workers <= 0 {
+		return DefaultFetchWorkers
+	}
+	return ds.Workers
+}
+
+// fetchEach calls fetch for every session on at most workers goroutines.
+// Values and errors keep the order of sessions, whatever order the requests
+// complete in; sessions not yet started when ctx is cancelled report ctx's error.
+func fetchEach[T any](ctx context.Context, workers int, sessions []Session,
+	fetch func(context.Context, Session) (T, error)) ([]T, []error) {
+	values := make([]T, len(sessions))
+	errs := make([]error, len(sessions))
+	if len(sessions) == 0 {
+		return values, errs
+	}
+	if workers > len(sessions) {
+		workers = len(sessions)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				// Each worker writes only its own index, so no locking is needed
+				values[i], errs[i] = fetch(ctx, sessions[i])
+			}
+		}()
+	}
+
+	for i := range sessions {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return values, errs
+}
+
+// FirstFetchError returns the error of the earliest failed session in fetch
+// order, so the same failure is reported no matter which request failed first.
+func FirstFetchError(fetches []SessionFetch) error {
+	for _, fetch := range fetches {
+		if fetch.Err != nil {
+			return fmt.Errorf("%s %s (session %d): %w",
+				fetch.Session.Location, fetch.Session.Name, fetch.Session.Key, fetch.Err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Interval is a stretch of time within a session
+type Interval struct {
+	Start time.Time
+	End   time.Time
+	// Reason says why running was neutralised, e.g. "Red flag" or "Yellow (sector 2)"
+	Reason string
+}
+
+// Overlaps reports whether the interval and [start, end] share any time
+func (i Interval) Overlaps(start, end time.Time) bool {
+	return start.Before(i.End) && end.After(i.Start)
+}
+
+// End returns when the lap finished, or its start if it wasn't timed
+func (l Lap) End() time.Time {
+	return l.Start.Add(time.Duration(l.Duration * float64(time.Second)))
+}
+
+// NeutralisedPeriods works out from race control messages when cars weren't
+// free to run at green-flag pace: red flags, full and virtual safety cars,
+// and yellow flags in any sector. A period still open when the messages run
+// out lasts until sessionEnd.
+func NeutralisedPeriods(messages []RaceControlMessage, sessionEnd time.Time) []Interval {
+	open := make(map[string]time.Time)
+	var periods []Interval
+
+	start := func(key string, at time.Time) {
+		if _, ok := open[key]; !ok {
+			open[key] = at
+		}
+	}
+	stop := func(key string, at time.Time) {
+		if since, ok := open[key]; ok {
+			periods = append(periods, Interval{Start: since, End: at, Reason: neutralisedReason(key)})
+			delete(open, key)
+		}
+	}
+	stopAll := func(at time.Time, keepSafetyCar bool) {
+		for key := range open {
+			if !(keepSafetyCar && key == "sc") {
+				stop(key, at)
+			}
+		}
+	}
+
+	for _, message := range messages {
+		text := strings.ToUpper(message.Message)
+		flag := strings.ToUpper(message.Flag)
+
+		switch {
+		case strings.EqualFold(message.Category, "SafetyCar"):
+			switch {
+			case strings.Contains(text, "DEPLOYED"):
+				start("sc", message.Date)
+			case strings.Contains(text, "ENDING"), strings.Contains(text, "IN THIS LAP"):
+				stop("sc", message.Date)
+			}
+		case flag == "RED":
+			start("red", message.Date)
+		case flag == "CHEQUERED":
+			stopAll(message.Date, false)
+		case strings.EqualFold(message.Scope, "Sector") && (flag == "YELLOW" || flag == "DOUBLE YELLOW"):
+			start(fmt.Sprintf("sector:%d", message.Sector), message.Date)
+		case strings.EqualFold(message.Scope, "Sector") && (flag == "CLEAR" || flag == "GREEN"):
+			stop(fmt.Sprintf("sector:%d", message.Sector), message.Date)
+		case strings.EqualFold(message.Scope, "Track") && (flag == "CLEAR" || flag == "GREEN"):
+			// A green light at the end of the pit lane restarts running, but
+			// doesn't bring a safety car in
+			stopAll(message.Date, true)
+		}
+	}
+	stopAll(sessionEnd, false)
+
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Start.Before(periods[j].Start) })
+	return periods
+}
+
+// neutralisedReason describes a neutralised period by its key
+func neutralisedReason(key string) string {
+	switch {
+	case key == "red":
+		return "Red flag"
+	case key == "sc":
+		return "Safety car"
+	case strings.HasPrefix(key, "sector:"):
+		return fmt.Sprintf("Yellow (sector %s)", strings.TrimPrefix(key, "sector:"))
+	}
+	return key
+}
+
+// IsGreenFlagLap reports whether a timed lap was run without any neutralised
+// period overlapping it
+func IsGreenFlagLap(lap Lap, periods []Interval) bool {
+	if lap.Start.IsZero() || lap.Duration <= 0 {
+		return false
+	}
+	for _, period := range periods {
+		if period.Overlaps(lap.Start, lap.End()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package data
+
+import "time"
+
+// SessionState is where a session is in its lifecycle
+type SessionState string
+
+const (
+	// StateUpcoming sessions have not started
+	StateUpcoming SessionState = "upcoming"
+	// StateLive sessions are running; their classification is partial
+	StateLive SessionState = "live"
+	// StateProvisional sessions have ended recently enough that stewards'
+	// decisions may still change the result
+	StateProvisional SessionState = "provisional"
+	// StateFinished sessions have final results
+	StateFinished SessionState = "finished"
+)
+
+// ProvisionalPeriod is how long after a session ends its result is treated as provisional
+const ProvisionalPeriod = finishedSessionGrace
+
+// expectedDuration estimates a session's length when the source gives no end time
+func expectedDuration(session Session) time.Duration {
+	if session.Name == "Race" {
+		return 2 * time.Hour
+	}
+	return time.Hour
+}
+
+// End returns when the session ended or is due to end
+func (s Session) End() time.Time {
+	if !s.DateEnd.IsZero() {
+		return s.DateEnd
+	}
+	return s.DateStart.Add(expectedDuration(s))
+}
+
+// StateAt returns the session's lifecycle state at the given time
+func (s Session) StateAt(now time.Time) SessionState {
+	switch {
+	case now.Before(s.DateStart):
+		return StateUpcoming
+	case now.Before(s.End()):
+		return StateLive
+	case now.Before(s.End().Add(ProvisionalPeriod)):
+		return StateProvisional
+	default:
+		return StateFinished
+	}
+}
+
+// State returns the session's lifecycle state now
+func (s Session) State() SessionState {
+	return s.StateAt(time.Now())
+}
+
+// HasEnded reports whether the session is over, even if its result is still provisional
+func (s Session) HasEnded() bool {
+	state := s.State()
+	return state == StateProvisional || state == StateFinished
+}
+
+// raceStatus maps a race session's state to the schedule's status strings
+func raceStatus(state SessionState) string {
+	switch state {
+	case StateUpcoming:
+		return "upcoming"
+	case StateLive:
+		return "live"
+	case StateProvisional:
+		return "provisional"
+	default:
+		return "completed"
+	}
+}
@@ -0,0 +1,324 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// accentFolds maps accented Latin letters to their plain forms, so that
+// "Sao Paulo" finds "São Paulo" and "Nurburgring" finds "Nürburgring"
+var accentFolds = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'č': "c",
+	'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ę': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'ř': "r",
+	'ś': "s", 'š': "s", 'ş': "s", 'ß': "ss",
+	'ť': "t", 'ţ': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'æ': "ae", 'œ': "oe",
+}
+
+// NormalizeName lowercases s, strips accents and turns punctuation into
+// single spaces, for comparing names typed by users with names from the API
+func NormalizeName(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if folded, ok := accentFolds[r]; ok {
+			b.WriteString(folded)
+			space = false
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space && b.Len() > 0 {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Match quality, best first
+const (
+	matchExact      = 100
+	matchPrefix     = 80
+	matchWordPrefix = 70
+	matchContains   = 50
+)
+
+// scoreName rates how well a normalized query matches a name
+func scoreName(name, query string) int {
+	name = NormalizeName(name)
+	switch {
+	case name == "" || query == "":
+		return 0
+	case name == query:
+		return matchExact
+	case strings.HasPrefix(name, query):
+		return matchPrefix
+	case strings.Contains(" "+name, " "+query):
+		return matchWordPrefix
+	case strings.Contains(name, query):
+		return matchContains
+	}
+	return 0
+}
+
+// SessionMatch is a session found by MatchSessions and how well it matched
+type SessionMatch struct {
+	Session Session
+	Score   int
+	// Field names what matched: "location", "country", "circuit" or "meeting"
+	Field string
+}
+
+// MatchSessions finds the sessions whose location, country, circuit or
+// meeting name match query, ignoring case and accents. Matches come back
+// best first, in calendar order among equals.
+func MatchSessions(sessions []Session, query string) []SessionMatch {
+	query = NormalizeName(query)
+
+	var matches []SessionMatch
+	for _, session := range sessions {
+		best := SessionMatch{Session: session}
+		fields := []struct{ name, value string }{
+			{"location", session.Location},
+			{"circuit", session.Circuit},
+			{"meeting", session.MeetingName},
+			{"country", session.Country},
+		}
+		for _, field := range fields {
+			if score := scoreName(field.value, query); score > best.Score {
+				best.Score, best.Field = score, field.name
+			}
+		}
+		if best.Score > 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+// BestSessionMatch returns the single best match, or false together with the
+// equally good candidates when query matches more than one meeting
+func BestSessionMatch(matches []SessionMatch) (SessionMatch, []SessionMatch, bool) {
+	if len(matches) == 0 {
+		return SessionMatch{}, nil, false
+	}
+
+	var tied []SessionMatch
+	meetings := make(map[string]bool)
+	for _, match := range matches {
+		if match.Score != matches[0].Score {
+			break
+		}
+		tied = append(tied, match)
+		meetings[meetingID(match.Session)] = true
+	}
+
+	if len(meetings) > 1 {
+		return SessionMatch{}, tied, false
+	}
+	return matches[0], nil, true
+}
+
+// meetingID identifies the meeting a session belongs to across data sources
+func meetingID(session Session) string {
+	return fmt.Sprintf("%d/%d/%s", session.MeetingKey, session.Round, session.Location)
+}
+
+// DriverMatchError is returned by ResolveDriver when a query names no driver,
+// or more than one
+type DriverMatchError struct {
+	Query string
+	// Ambiguous is set when Candidates all match equally well; otherwise
+	// Candidates are near misses worth suggesting
+	Ambiguous  bool
+	Candidates []Driver
+}
+
+func (e *DriverMatchError) Error() string {
+	names := make([]string, len(e.Candidates))
+	for i, driver := range e.Candidates {
+		names[i] = driver.Name
+	}
+
+	switch {
+	case e.Ambiguous:
+		return fmt.Sprintf("'%s' matches more than one driver: %s", e.Query, strings.Join(names, ", "))
+	case len(names) > 0:
+		return fmt.Sprintf("driver '%s' not found; did you mean %s?", e.Query, strings.Join(names, " or "))
+	}
+	return fmt.Sprintf("driver '%s' not found", e.Query)
+}
+
+// Surname returns the driver's family name, falling back to the last word of
+// their full name
+func (d Driver) Surname() string {
+	if d.LastName != "" {
+		return d.LastName
+	}
+	words := strings.Fields(d.Name)
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// Code returns the driver's three-letter acronym (VER, HAM), deriving one
+// from their surname when the source has none
+func (d Driver) Code() string {
+	if d.Acronym != "" {
+		return strings.ToUpper(d.Acronym)
+	}
+	surname := strings.ReplaceAll(NormalizeName(d.Surname()), " ", "")
+	if len(surname) > 3 {
+		surname = surname[:3]
+	}
+	return strings.ToUpper(surname)
+}
+
+// ResolveDriver finds the driver a user means. It accepts a car number, a
+// three-letter acronym, a full name or surname, or any part of the name,
+// ignoring case and accents, and finally tolerates a typo or two. When
+// nothing fits, or several drivers fit equally well, the error is a
+// *DriverMatchError listing the candidates.
+func ResolveDriver(drivers []Driver, query string) (Driver, error) {
+	normalized := NormalizeName(query)
+	if normalized == "" {
+		return Driver{}, &DriverMatchError{Query: query}
+	}
+
+	if number, err := strconv.Atoi(normalized); err == nil {
+		for _, driver := range drivers {
+			if driver.Number == number {
+				return driver, nil
+			}
+		}
+		return Driver{}, &DriverMatchError{Query: query}
+	}
+
+	// Each rule is tried in turn, strictest first; the first that matches
+	// anyone decides
+	rules := []func(Driver) bool{
+		func(d Driver) bool { return NormalizeName(d.Name) == normalized },
+		func(d Driver) bool { return len(normalized) == 3 && NormalizeName(d.Code()) == normalized },
+		func(d Driver) bool { return NormalizeName(d.Surname()) == normalized },
+		func(d Driver) bool { return scoreName(d.Name, normalized) >= matchWordPrefix },
+		func(d Driver) bool { return scoreName(d.Name, normalized) >= matchContains },
+	}
+	for _, rule := range rules {
+		var matched []Driver
+		for _, driver := range drivers {
+			if rule(driver) {
+				matched = append(matched, driver)
+			}
+		}
+		switch {
+		case len(matched) == 1:
+			return matched[0], nil
+		case len(matched) > 1:
+			return Driver{}, &DriverMatchError{Query: query, Ambiguous: true, Candidates: matched}
+		}
+	}
+
+	// One clearly closest spelling is taken as meant; otherwise the near
+	// misses are only suggested
+	near := nearDrivers(drivers, normalized)
+	if len(near) > 0 && near[0].distance <= typoAllowance(normalized) &&
+		(len(near) == 1 || near[1].distance > near[0].distance) {
+		return near[0].driver, nil
+	}
+
+	suggestions := make([]Driver, 0, maxSuggestions)
+	for _, candidate := range near {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, candidate.driver)
+	}
+	return Driver{}, &DriverMatchError{Query: query, Candidates: suggestions}
+}
+
+// maxSuggestions caps the "did you mean" list
+const maxSuggestions = 3
+
+// typoAllowance is how many edits a query may be from a name and still be
+// accepted outright: one for short queries, two from eight letters
+func typoAllowance(query string) int {
+	if len([]rune(query)) >= 8 {
+		return 2
+	}
+	return 1
+}
+
+type nearDriver struct {
+	driver   Driver
+	distance int
+}
+
+// nearDrivers returns the drivers whose full name, surname or acronym is
+// within a few edits of query, closest first
+func nearDrivers(drivers []Driver, query string) []nearDriver {
+	limit := typoAllowance(query) + 1
+
+	var near []nearDriver
+	for _, driver := range drivers {
+		best := -1
+		for _, name := range []string{driver.Name, driver.Surname(), driver.Code()} {
+			distance := editDistance(NormalizeName(name), query)
+			if best < 0 || distance < best {
+				best = distance
+			}
+		}
+		if best >= 0 && best <= limit {
+			near = append(near, nearDriver{driver: driver, distance: best})
+		}
+	}
+
+	sort.SliceStable(near, func(i, j int) bool {
+		return near[i].distance < near[j].distance
+	})
+	return near
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
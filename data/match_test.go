@@ -0,0 +1,148 @@
+package data
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveDriver(t *testing.T) {
+	drivers := []Driver{
+		{Name: "Max Verstappen", LastName: "Verstappen", Acronym: "VER", Number: 1},
+		{Name: "Lewis Hamilton", LastName: "Hamilton", Acronym: "HAM", Number: 44},
+		{Name: "Charles Leclerc", LastName: "Leclerc", Acronym: "LEC", Number: 16},
+		{Name: "Arthur Leclerc", LastName: "Leclerc", Acronym: "ALC", Number: 39},
+		{Name: "Carlos Sainz", LastName: "Sainz", Acronym: "SAI", Number: 55},
+		{Name: "Nico Hülkenberg", LastName: "Hülkenberg", Acronym: "HUL", Number: 27},
+		{Name: "Andrea Kimi Antonelli", LastName: "Antonelli", Acronym: "ANT", Number: 12},
+		// No acronym from the source; one is derived from the surname
+		{Name: "Oliver Bearman", Number: 87},
+	}
+
+	tests := []struct {
+		query string
+		// want is the driver number found, or 0 for an error
+		want int
+		// ambiguous and candidates describe the expected *DriverMatchError
+		ambiguous  bool
+		candidates []int
+	}{
+		{query: "VER", want: 1},
+		{query: "ham", want: 44},
+		{query: "bea", want: 87},
+		{query: "44", want: 44},
+		{query: "#55", want: 55},
+		{query: "99"},
+		{query: "Hamilton", want: 44},
+		{query: "hulkenberg", want: 27},
+		{query: "Max Verstappen", want: 1},
+		{query: "charles", want: 16},
+		{query: "kimi", want: 12},
+		{query: "leclerc", ambiguous: true, candidates: []int{16, 39}},
+		{query: "Verstapen", want: 1},
+		{query: "sanz", want: 55},
+		{query: "hmiltn", candidates: []int{44}},
+		{query: "xyzzy"},
+		{query: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			driver, err := ResolveDriver(drivers, tt.query)
+			if tt.want != 0 {
+				if err != nil || driver.Number != tt.want {
+					t.Fatalf("ResolveDriver(%q) = #%d, %v; want #%d", tt.query, driver.Number, err, tt.want)
+				}
+				return
+			}
+
+			var matchErr *DriverMatchError
+			if !errors.As(err, &matchErr) {
+				t.Fatalf("ResolveDriver(%q) = #%d, %v; want a *DriverMatchError", tt.query, driver.Number, err)
+			}
+			var candidates []int
+			for _, candidate := range matchErr.Candidates {
+				candidates = append(candidates, candidate.Number)
+			}
+			if matchErr.Ambiguous != tt.ambiguous || !equalInts(candidates, tt.candidates) {
+				t.Errorf("ResolveDriver(%q) error = %+v, want ambiguous %v with %v",
+					tt.query, matchErr, tt.ambiguous, tt.candidates)
+			}
+		})
+	}
+}
+
+func TestMatchSessionsIgnoresCaseAndAccents(t *testing.T) {
+	sessions := []Session{
+		{Key: 1, Round: 8, MeetingKey: 108, Location: "Montréal", Country: "Canada",
+			Circuit: "Circuit Gilles-Villeneuve", MeetingName: "Canadian Grand Prix"},
+		{Key: 2, Round: 21, MeetingKey: 121, Location: "São Paulo", Country: "Brazil",
+			Circuit: "Autódromo José Carlos Pace", MeetingName: "São Paulo Grand Prix"},
+		{Key: 3, Round: 12, MeetingKey: 112, Location: "Silverstone", Country: "Great Britain",
+			Circuit: "Silverstone", MeetingName: "British Grand Prix"},
+		{Key: 4, Round: 22, MeetingKey: 122, Location: "Las Vegas", Country: "United States",
+			Circuit: "Las Vegas", MeetingName: "Las Vegas Grand Prix"},
+		{Key: 5, Round: 19, MeetingKey: 119, Location: "Austin", Country: "United States",
+			Circuit: "Circuit of the Americas", MeetingName: "United States Grand Prix"},
+	}
+
+	tests := []struct {
+		query string
+		// want is the key of the session found, or 0 for none; ambiguous
+		// queries list the keys of the tied candidates instead
+		want  int
+		field string
+		tied  []int
+	}{
+		{query: "sao paulo", want: 2, field: "location"},
+		{query: "SÃO PAULO", want: 2, field: "location"},
+		{query: "MONTREAL", want: 1, field: "location"},
+		{query: "montréal", want: 1, field: "location"},
+		{query: "interlagos"},
+		{query: "jose carlos", want: 2, field: "circuit"},
+		{query: "canada", want: 1, field: "country"},
+		{query: "british", want: 3, field: "meeting"},
+		{query: "silver", want: 3, field: "location"},
+		{query: "united states", tied: []int{4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			best, tied, ok := BestSessionMatch(MatchSessions(sessions, tt.query))
+			if len(tt.tied) > 0 {
+				var keys []int
+				for _, match := range tied {
+					keys = append(keys, match.Session.Key)
+				}
+				if ok || !equalInts(keys, tt.tied) {
+					t.Errorf("MatchSessions(%q) tied = %v, ok %v; want %v", tt.query, keys, ok, tt.tied)
+				}
+				return
+			}
+			if tt.want == 0 {
+				if ok {
+					t.Errorf("MatchSessions(%q) found %s", tt.query, best.Session.Location)
+				}
+				return
+			}
+			if !ok || best.Session.Key != tt.want || best.Field != tt.field {
+				t.Errorf("MatchSessions(%q) = key %d on %q, ok %v; want key %d on %q",
+					tt.query, best.Session.Key, best.Field, ok, tt.want, tt.field)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"São Paulo":                 "sao paulo",
+		"MONTRÉAL":                  "montreal",
+		"Nürburgring":               "nurburgring",
+		"Circuit Gilles-Villeneuve": "circuit gilles villeneuve",
+		"  Spa--Francorchamps ":     "spa francorchamps",
+	}
+	for in, want := range tests {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
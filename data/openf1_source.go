@@ -0,0 +1,363 @@
+package data
+
+import (
+	"context"
+)
+
+// OpenF1Source adapts APIClient to the DataSource interface
+type OpenF1Source struct {
+	client *APIClient
+}
+
+// NewOpenF1Source wraps an OpenF1 API client
+func NewOpenF1Source(client *APIClient) *OpenF1Source {
+	return &OpenF1Source{client: client}
+}
+
+// Client returns the wrapped API client for OpenF1-specific endpoints
+func (s *OpenF1Source) Client() *APIClient {
+	return s.client
+}
+
+func (s *OpenF1Source) Name() string {
+	return "OpenF1 API"
+}
+
+func (s *OpenF1Source) FirstSeason() int {
+	return FirstOpenF1Season
+}
+
+func (s *OpenF1Source) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx)
+}
+
+func (s *OpenF1Source) Drivers(ctx context.Context, season int) ([]Driver, error) {
+	return s.client.GetDriversContext(ctx, season)
+}
+
+func (s *OpenF1Source) Sessions(ctx context.Context, season int) ([]Session, error) {
+	openF1Sessions, err := s.client.GetAllRaceAndSprintSessionsContext(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSessions(ctx, season, openF1Sessions, raceRounds(openF1Sessions))
+}
+
+// WeekendSessions returns a season's sessions of one type, numbered with the
+// round of the race they belong to
+func (s *OpenF1Source) WeekendSessions(ctx context.Context, season int, sessionType string) ([]Session, error) {
+	openF1Sessions, err := s.client.GetSessionsByTypeContext(ctx, season, sessionType)
+	if err != nil {
+		return nil, err
+	}
+	races, err := s.client.GetRaceSessionsContext(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSessions(ctx, season, openF1Sessions, raceRounds(races))
+}
+
+// toSessions converts OpenF1 sessions, adding their round and meeting name
+func (s *OpenF1Source) toSessions(ctx context.Context, season int, openF1Sessions []OpenF1Session, rounds map[int]int) ([]Session, error) {
+	// Meeting names ("British Grand Prix") help users find sessions; when
+	// the API has none the location stands in
+	meetingNames := make(map[int]string)
+	meetings, err := s.client.GetMeetingsContext(ctx, season)
+	if err != nil && !isNoData(err) {
+		return nil, err
+	}
+	for _, meeting := range meetings {
+		meetingNames[meeting.MeetingKey] = meeting.MeetingName
+	}
+
+	sessions := make([]Session, len(openF1Sessions))
+	for i, session := range openF1Sessions {
+		sessions[i] = session.toSession()
+		sessions[i].Round = rounds[session.MeetingKey]
+		if name := meetingNames[session.MeetingKey]; name != "" {
+			sessions[i].MeetingName = name
+		}
+	}
+	return sessions, nil
+}
+
+// Classification prefers the official session_result classification and
+// falls back to the position stream while it has not been published
+func (s *OpenF1Source) Classification(ctx context.Context, session Session) ([]SessionResult, error) {
+	official, err := s.client.GetSessionClassificationContext(ctx, session.Key)
+	if err != nil && !isNoData(err) {
+		return nil, err
+	}
+	if len(official) > 0 {
+		results := make([]SessionResult, len(official))
+		for i, row := range official {
+			gap, gapSeconds := row.Gap()
+			results[i] = SessionResult{
+				DriverNumber: row.DriverNumber,
+				Position:     row.Position,
+				Status:       row.ClassificationStatus(),
+				Laps:         row.NumberOfLaps,
+				Gap:          gap,
+				GapSeconds:   gapSeconds,
+				DNF:          row.DNF,
+				DNS:          row.DNS,
+				DSQ:          row.DSQ,
+			}
+		}
+		return s.withEntrants(ctx, session, results)
+	}
+
+	positions, err := s.client.GetSessionResultsContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SessionResult, len(positions))
+	for i, pos := range positions {
+		results[i] = SessionResult{
+			DriverNumber: pos.DriverNumber,
+			Position:     pos.Position,
+		}
+	}
+	return s.withEntrants(ctx, session, results)
+}
+
+// entrants maps each driver in a session to their entry, including the team
+// they entered it with, which can differ from their current team
+func (s *OpenF1Source) entrants(ctx context.Context, session Session) (map[int]OpenF1Driver, error) {
+	entrants, err := s.client.GetSessionDriversContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[int]OpenF1Driver)
+	for _, entrant := range entrants {
+		byNumber[entrant.DriverNumber] = entrant
+	}
+	return byNumber, nil
+}
+
+// withEntrants fills in each driver's name and the team they entered the session with
+func (s *OpenF1Source) withEntrants(ctx context.Context, session Session, results []SessionResult) ([]SessionResult, error) {
+	byNumber, err := s.entrants(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if entrant, ok := byNumber[results[i].DriverNumber]; ok {
+			results[i].Driver = entrant.FullName
+			results[i].Team = entrant.TeamName
+		}
+	}
+	return results, nil
+}
+
+// Qualifying prefers the official session_result times and rebuilds the
+// classification from lap times while it has not been published
+func (s *OpenF1Source) Qualifying(ctx context.Context, session Session) ([]QualifyingResult, error) {
+	official, err := s.client.GetSessionClassificationContext(ctx, session.Key)
+	if err != nil && !isNoData(err) {
+		return nil, err
+	}
+
+	var results []QualifyingResult
+	for _, row := range official {
+		results = append(results, QualifyingResult{
+			DriverNumber: row.DriverNumber,
+			Position:     row.Position,
+			Times:        row.SegmentTimes(),
+			Laps:         row.NumberOfLaps,
+		})
+	}
+	if len(results) > 0 {
+		setSegments(results)
+	} else {
+		laps, err := s.Laps(ctx, session, 0)
+		if err != nil {
+			return nil, err
+		}
+		results = qualifyingFromLaps(laps)
+	}
+
+	byNumber, err := s.entrants(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if entrant, ok := byNumber[results[i].DriverNumber]; ok {
+			results[i].Driver = entrant.FullName
+			results[i].Team = entrant.TeamName
+		}
+	}
+	return results, nil
+}
+
+// Laps returns a session's laps, or one driver's when driverNumber isn't zero
+func (s *OpenF1Source) Laps(ctx context.Context, session Session, driverNumber int) ([]Lap, error) {
+	openF1Laps, err := s.client.GetLapsContext(ctx, session.Key, driverNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	laps := make([]Lap, len(openF1Laps))
+	for i, lap := range openF1Laps {
+		laps[i] = Lap{
+			DriverNumber: lap.DriverNumber,
+			Number:       lap.LapNumber,
+			Start:        lap.DateStart,
+			Duration:     lap.LapDuration,
+			Sectors:      [3]float64{lap.DurationSector1, lap.DurationSector2, lap.DurationSector3},
+			PitOut:       lap.IsPitOutLap,
+			SpeedI1:      lap.I1Speed,
+			SpeedI2:      lap.I2Speed,
+			SpeedTrap:    lap.SpeedTrapSpeed,
+		}
+	}
+	return laps, nil
+}
+
+// Stints returns a session's tyre stints, or one driver's when driverNumber isn't zero
+func (s *OpenF1Source) Stints(ctx context.Context, session Session, driverNumber int) ([]Stint, error) {
+	openF1Stints, err := s.client.GetStintsContext(ctx, session.Key, driverNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	stints := make([]Stint, len(openF1Stints))
+	for i, stint := range openF1Stints {
+		stints[i] = Stint{
+			DriverNumber: stint.DriverNumber,
+			Number:       stint.StintNumber,
+			Compound:     stint.Compound,
+			LapStart:     stint.LapStart,
+			LapEnd:       stint.LapEnd,
+			TyreAge:      stint.TyreAgeAtStart,
+		}
+	}
+	return stints, nil
+}
+
+// Weather returns a session's weather readings in time order
+func (s *OpenF1Source) Weather(ctx context.Context, session Session) ([]WeatherSample, error) {
+	readings, err := s.client.GetWeatherContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]WeatherSample, len(readings))
+	for i, reading := range readings {
+		samples[i] = WeatherSample{
+			Time:          reading.Date,
+			AirTemp:       reading.AirTemperature,
+			TrackTemp:     reading.TrackTemperature,
+			Humidity:      reading.Humidity,
+			Pressure:      reading.Pressure,
+			Rain:          reading.Rainfall > 0,
+			WindSpeed:     reading.WindSpeed,
+			WindDirection: reading.WindDirection,
+		}
+	}
+	return samples, nil
+}
+
+// PitStops returns a session's pit stops in time order
+func (s *OpenF1Source) PitStops(ctx context.Context, session Session) ([]PitStop, error) {
+	pits, err := s.client.GetPitsContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	stops := make([]PitStop, len(pits))
+	for i, pit := range pits {
+		stops[i] = PitStop{DriverNumber: pit.DriverNumber, Lap: pit.LapNumber, Date: pit.Date}
+		switch {
+		case pit.LaneDuration != nil:
+			stops[i].LaneTime = *pit.LaneDuration
+		case pit.PitDuration != nil:
+			stops[i].LaneTime = *pit.PitDuration
+		}
+		if pit.StopDuration != nil {
+			stops[i].StopTime = *pit.StopDuration
+		}
+	}
+	return stops, nil
+}
+
+// RaceControl returns a session's race control messages in time order
+func (s *OpenF1Source) RaceControl(ctx context.Context, session Session) ([]RaceControlMessage, error) {
+	openF1Messages, err := s.client.GetRaceControlContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]RaceControlMessage, len(openF1Messages))
+	for i, message := range openF1Messages {
+		messages[i] = RaceControlMessage{
+			Date:         message.Date,
+			Category:     message.Category,
+			Flag:         message.Flag,
+			Scope:        message.Scope,
+			Sector:       message.Sector,
+			DriverNumber: message.DriverNumber,
+			Lap:          message.LapNumber,
+			Message:      message.Message,
+		}
+	}
+	return messages, nil
+}
+
+// Entrants returns the drivers entered in a session
+func (s *OpenF1Source) Entrants(ctx context.Context, session Session) ([]Driver, error) {
+	entrants, err := s.client.GetSessionDriversContext(ctx, session.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	drivers := make([]Driver, 0, len(entrants))
+	seen := make(map[int]bool)
+	for _, entrant := range entrants {
+		if seen[entrant.DriverNumber] {
+			continue
+		}
+		seen[entrant.DriverNumber] = true
+		drivers = append(drivers, Driver{
+			ID:       entrant.DriverNumber,
+			Name:     entrant.FullName,
+			LastName: entrant.LastName,
+			Acronym:  entrant.NameAcronym,
+			Number:   entrant.DriverNumber,
+			Team:     entrant.TeamName,
+			Country:  entrant.CountryCode,
+		})
+	}
+	return drivers, nil
+}
+
+func (s *OpenF1Source) Schedule(ctx context.Context, season int) ([]Race, error) {
+	return s.client.GetRaceScheduleContext(ctx, season)
+}
+
+func (s *OpenF1Source) Cache() *ResponseCache {
+	return s.client.Cache
+}
+
+func (s *OpenF1Source) SetCache(cache *ResponseCache) {
+	s.client.Cache = cache
+}
+
+// toSession converts an OpenF1 session into the source-neutral Session
+func (session OpenF1Session) toSession() Session {
+	return Session{
+		Key:         session.SessionKey,
+		Name:        session.SessionName,
+		Type:        session.SessionType,
+		MeetingKey:  session.MeetingKey,
+		MeetingName: session.Location,
+		Location:    session.Location,
+		Country:     session.CountryName,
+		Circuit:     session.CircuitShortName,
+		Year:        session.Year,
+		DateStart:   session.DateStart,
+		DateEnd:     session.DateEnd,
+	}
+}
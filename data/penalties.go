@@ -0,0 +1,264 @@
+package data
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LedgerVersion is the ledger format this build understands
+const LedgerVersion = 1
+
+// Correction types recorded in the ledger
+const (
+	// CorrectionDisqualification removes a driver from the classification
+	CorrectionDisqualification = "disqualification"
+	// CorrectionTimePenalty is a post-race time penalty. Seconds is added to
+	// the driver's race time and the finishers are reordered on it; Position
+	// optionally pins the result instead.
+	CorrectionTimePenalty = "time_penalty"
+	// CorrectionReinstatement cancels an earlier disqualification
+	CorrectionReinstatement = "reinstatement"
+	// CorrectionPointsDeduction takes Points away from the driver's score for the session
+	CorrectionPointsDeduction = "points_deduction"
+	// CorrectionPositionChange moves a driver to Position, e.g. after others were penalised
+	CorrectionPositionChange = "position_change"
+)
+
+// BuiltinLedgerName labels corrections that come from the ledger shipped with the binary
+const BuiltinLedgerName = "built-in"
+
+//go:embed penalties.json
+var builtinLedger []byte
+
+// Correction is a single stewards' decision applied on top of a session classification
+type Correction struct {
+	// ID names the correction; an override ledger replaces built-in entries with the same ID
+	ID string `json:"id"`
+	// Season, Location and Session identify the session independently of the data source
+	Season   int    `json:"season"`
+	Location string `json:"location"`
+	Session  string `json:"session"`
+	// SessionKey optionally pins the correction to an OpenF1 session
+	SessionKey   int     `json:"session_key,omitempty"`
+	Type         string  `json:"type"`
+	DriverNumber int     `json:"driver_number"`
+	Position     int     `json:"position,omitempty"`
+	Seconds      float64 `json:"seconds,omitempty"`
+	Points       float64 `json:"points,omitempty"`
+	// Reason explains why the correction exists
+	Reason string `json:"reason"`
+	// Reference points to the stewards' document, if any
+	Reference string `json:"reference,omitempty"`
+
+	// Origin is the ledger file the correction was read from
+	Origin string `json:"-"`
+}
+
+// Ledger is a versioned list of stewards' decisions
+type Ledger struct {
+	Version     int          `json:"version"`
+	Corrections []Correction `json:"corrections"`
+
+	// Path is the override file merged into the built-in ledger, if any
+	Path string `json:"-"`
+}
+
+// Matches reports whether the correction applies to session
+func (c Correction) Matches(session Session) bool {
+	if c.SessionKey != 0 && c.SessionKey == session.Key {
+		return true
+	}
+	return c.Season == session.Season() &&
+		strings.EqualFold(c.Session, session.Name) &&
+		c.Location != "" && strings.EqualFold(c.Location, session.Location)
+}
+
+// ParseLedger decodes a ledger and tags every correction with origin
+func ParseLedger(raw []byte, origin string) (*Ledger, error) {
+	var ledger Ledger
+	if err := json.Unmarshal(raw, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse penalties ledger %s: %w", origin, err)
+	}
+	if ledger.Version != LedgerVersion {
+		return nil, fmt.Errorf("penalties ledger %s has version %d, expected %d",
+			origin, ledger.Version, LedgerVersion)
+	}
+
+	for i := range ledger.Corrections {
+		ledger.Corrections[i].Origin = origin
+	}
+	return &ledger, nil
+}
+
+// BuiltinLedger returns the ledger shipped with the binary
+func BuiltinLedger() *Ledger {
+	ledger, err := ParseLedger(builtinLedger, BuiltinLedgerName)
+	if err != nil {
+		// The embedded file is part of the source tree; failing here is a build mistake
+		panic(err)
+	}
+	return ledger
+}
+
+// DefaultPenaltiesPath returns the override ledger location inside the user config dir
+func DefaultPenaltiesPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config dir: %w", err)
+	}
+	return filepath.Join(base, "f1cli", "penalties.json"), nil
+}
+
+// LoadLedger returns the built-in ledger with the override file at path
+// merged on top. A missing file is not an error and yields the built-in ledger.
+func LoadLedger(path string) (*Ledger, error) {
+	ledger := BuiltinLedger()
+	ledger.Path = path
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return ledger, fmt.Errorf("failed to read penalties ledger: %w", err)
+	}
+
+	override, err := ParseLedger(raw, path)
+	if err != nil {
+		return ledger, err
+	}
+
+	ledger.Merge(override)
+	return ledger, nil
+}
+
+// Merge adds the corrections from other, replacing any with the same ID
+func (l *Ledger) Merge(other *Ledger) {
+	index := make(map[string]int)
+	for i, correction := range l.Corrections {
+		if correction.ID != "" {
+			index[correction.ID] = i
+		}
+	}
+
+	for _, correction := range other.Corrections {
+		if i, exists := index[correction.ID]; exists && correction.ID != "" {
+			l.Corrections[i] = correction
+			continue
+		}
+		l.Corrections = append(l.Corrections, correction)
+	}
+}
+
+// ForSession returns the corrections that apply to session, in ledger order
+func (l *Ledger) ForSession(session Session) []Correction {
+	if l == nil {
+		return nil
+	}
+
+	var corrections []Correction
+	for _, correction := range l.Corrections {
+		if correction.Matches(session) {
+			corrections = append(corrections, correction)
+		}
+	}
+	return corrections
+}
+
+// ForSeason returns the corrections recorded for season, in ledger order
+func (l *Ledger) ForSeason(season int) []Correction {
+	if l == nil {
+		return nil
+	}
+
+	var corrections []Correction
+	for _, correction := range l.Corrections {
+		if correction.Season == season {
+			corrections = append(corrections, correction)
+		}
+	}
+	return corrections
+}
+
+// Validate checks every correction and returns one error per problem found
+func (l *Ledger) Validate() []error {
+	var problems []error
+	seen := make(map[string]bool)
+	// Positions already handed out, per session
+	positions := make(map[string]map[int]string)
+
+	for i, c := range l.Corrections {
+		label := c.ID
+		if label == "" {
+			label = fmt.Sprintf("correction #%d", i+1)
+		}
+		fail := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Errorf("%s: %s", label, fmt.Sprintf(format, args...)))
+		}
+
+		if c.ID == "" {
+			fail("missing id")
+		} else if seen[c.ID] {
+			fail("duplicate id")
+		}
+		seen[c.ID] = true
+
+		if c.Season == 0 {
+			fail("missing season")
+		}
+		if c.Session != "Race" && c.Session != "Sprint" {
+			fail("session must be \"Race\" or \"Sprint\", got %q", c.Session)
+		}
+		if c.Location == "" && c.SessionKey == 0 {
+			fail("needs a location or session_key to identify the session")
+		}
+		if c.DriverNumber <= 0 {
+			fail("missing driver_number")
+		}
+		if strings.TrimSpace(c.Reason) == "" {
+			fail("missing reason")
+		}
+		if c.Position < 0 {
+			fail("position must be positive")
+		}
+
+		switch c.Type {
+		case CorrectionDisqualification:
+			if c.Position != 0 {
+				fail("a disqualification cannot also set a position")
+			}
+		case CorrectionTimePenalty:
+			if c.Seconds <= 0 {
+				fail("time_penalty needs seconds")
+			}
+		case CorrectionReinstatement:
+		case CorrectionPointsDeduction:
+			if c.Points <= 0 {
+				fail("points_deduction needs points")
+			}
+		case CorrectionPositionChange:
+			if c.Position == 0 {
+				fail("position_change needs a position")
+			}
+		default:
+			fail("unknown type %q", c.Type)
+		}
+
+		if c.Position > 0 {
+			session := fmt.Sprintf("%d/%s/%s", c.Season, strings.ToLower(c.Location), c.Session)
+			if positions[session] == nil {
+				positions[session] = make(map[int]string)
+			}
+			if other, taken := positions[session][c.Position]; taken {
+				fail("position %d is already given by %s", c.Position, other)
+			}
+			positions[session][c.Position] = label
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinLedgerIsValid(t *testing.T) {
+	ledger, err := ParseLedger(builtinLedger, BuiltinLedgerName)
+	if err != nil {
+		t.Fatalf("ParseLedger: %v", err)
+	}
+	if len(ledger.Corrections) == 0 {
+		t.Fatal("the built-in ledger is empty")
+	}
+	for _, problem := range ledger.Validate() {
+		t.Errorf("built-in ledger: %v", problem)
+	}
+}
+
+func TestValidateRejectsBadCorrections(t *testing.T) {
+	valid := Correction{
+		ID: "2025-miami-sprint-verstappen", Season: 2025, Location: "Miami", Session: "Sprint",
+		Type: CorrectionTimePenalty, DriverNumber: 1, Seconds: 10, Reason: "Unsafe release",
+	}
+
+	tests := []struct {
+		name   string
+		change func(c *Correction)
+		want   string
+	}{
+		{"missing id", func(c *Correction) { c.ID = "" }, "missing id"},
+		{"missing season", func(c *Correction) { c.Season = 0 }, "missing season"},
+		{"qualifying", func(c *Correction) { c.Session = "Qualifying" }, "session must be"},
+		{"no session", func(c *Correction) { c.Location = "" }, "needs a location or session_key"},
+		{"no driver", func(c *Correction) { c.DriverNumber = 0 }, "missing driver_number"},
+		{"no reason", func(c *Correction) { c.Reason = " " }, "missing reason"},
+		{"negative position", func(c *Correction) { c.Position = -1 }, "position must be positive"},
+		{"time penalty without seconds", func(c *Correction) { c.Seconds = 0 }, "time_penalty needs seconds"},
+		{"unknown type", func(c *Correction) { c.Type = "grid_penalty" }, "unknown type"},
+		{"disqualified to a position", func(c *Correction) {
+			c.Type = CorrectionDisqualification
+			c.Position = 3
+		}, "cannot also set a position"},
+		{"deduction without points", func(c *Correction) { c.Type = CorrectionPointsDeduction }, "needs points"},
+		{"position change without position", func(c *Correction) { c.Type = CorrectionPositionChange }, "needs a position"},
+	}
+
+	if problems := (&Ledger{Version: LedgerVersion, Corrections: []Correction{valid}}).Validate(); len(problems) != 0 {
+		t.Fatalf("valid correction rejected: %v", problems)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			correction := valid
+			tt.change(&correction)
+			problems := (&Ledger{Version: LedgerVersion, Corrections: []Correction{correction}}).Validate()
+			if len(problems) != 1 || !strings.Contains(problems[0].Error(), tt.want) {
+				t.Errorf("problems = %v, want one containing %q", problems, tt.want)
+			}
+		})
+	}
+
+	// Two corrections can't hand out the same place, nor share an id
+	pinned := valid
+	pinned.Type, pinned.Position = CorrectionPositionChange, 4
+	other := pinned
+	other.ID, other.DriverNumber = "2025-miami-sprint-albon", 23
+	problems := (&Ledger{Corrections: []Correction{pinned, other, other}}).Validate()
+	if len(problems) != 3 {
+		t.Errorf("problems = %v, want a taken position twice and a duplicate id", problems)
+	}
+}
@@ -0,0 +1,224 @@
+package data
+
+import (
+	"sort"
+	"strings"
+)
+
+// LongRunMinLaps is the fewest representative laps in a row that count as a long run
+const LongRunMinLaps = 5
+
+// longRunSpread is how much slower than the stint's median a lap may be and
+// still be part of a run; slower laps are cool-down laps or traffic
+const longRunSpread = 1.05
+
+// PracticeResult is a driver's practice session at a glance
+type PracticeResult struct {
+	DriverNumber int
+	Driver       string
+	Team         string
+	// Best is the fastest lap in seconds, set on lap BestLap on BestCompound
+	Best         float64
+	BestLap      int
+	BestCompound string
+	Laps         int
+	// Compounds lists each compound used, in the order first fitted
+	Compounds []string
+}
+
+// LongRun is a stretch of consecutive green-flag laps at a steady pace on
+// one set of tyres, the kind teams run to rehearse the race
+type LongRun struct {
+	DriverNumber int
+	Compound     string
+	Stint        int
+	Laps         []Lap
+	// Average is the mean lap time of the run in seconds
+	Average float64
+}
+
+// CompoundLetter abbreviates a compound as on timing screens: S, M, H, I or W
+func CompoundLetter(compound string) string {
+	switch strings.ToUpper(compound) {
+	case "SOFT":
+		return "S"
+	case "MEDIUM":
+		return "M"
+	case "HARD":
+		return "H"
+	case "INTERMEDIATE":
+		return "I"
+	case "WET":
+		return "W"
+	case "", "UNKNOWN":
+		return "?"
+	}
+	return strings.ToUpper(compound[:1])
+}
+
+// StintForLap returns the stint a lap was run in, if the stints cover it
+func StintForLap(stints []Stint, lap Lap) (Stint, bool) {
+	for _, stint := range stints {
+		if stint.DriverNumber == lap.DriverNumber && lap.Number >= stint.LapStart &&
+			(stint.LapEnd == 0 || lap.Number <= stint.LapEnd) {
+			return stint, true
+		}
+	}
+	return Stint{}, false
+}
+
+// SummarisePractice finds each driver's best lap, lap count and tyres, fastest first
+func SummarisePractice(laps []Lap, stints []Stint) []PracticeResult {
+	byDriver := make(map[int]*PracticeResult)
+	var order []int
+	for _, lap := range laps {
+		result, ok := byDriver[lap.DriverNumber]
+		if !ok {
+			result = &PracticeResult{DriverNumber: lap.DriverNumber}
+			byDriver[lap.DriverNumber] = result
+			order = append(order, lap.DriverNumber)
+		}
+		result.Laps++
+
+		if lap.Duration > 0 && !lap.PitOut && (result.Best == 0 || lap.Duration < result.Best) {
+			result.Best, result.BestLap = lap.Duration, lap.Number
+			if stint, ok := StintForLap(stints, lap); ok {
+				result.BestCompound = stint.Compound
+			}
+		}
+	}
+
+	for _, stint := range stints {
+		result, ok := byDriver[stint.DriverNumber]
+		if !ok || stint.Compound == "" {
+			continue
+		}
+		used := false
+		for _, compound := range result.Compounds {
+			used = used || compound == stint.Compound
+		}
+		if !used {
+			result.Compounds = append(result.Compounds, stint.Compound)
+		}
+	}
+
+	results := make([]PracticeResult, len(order))
+	for i, number := range order {
+		results[i] = *byDriver[number]
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Best == 0) != (results[j].Best == 0) {
+			return results[j].Best == 0
+		}
+		return results[i].Best < results[j].Best
+	})
+	return results
+}
+
+// FindLongRuns picks out every long run in a session. Out laps, in laps and
+// laps touched by a neutralised period break a run, as do laps more than 5%
+// slower than the stint's median, which are cool-down laps or traffic.
+func FindLongRuns(laps []Lap, stints []Stint, neutralised []Interval) []LongRun {
+	type stintKey struct{ driver, stint int }
+	grouped := make(map[stintKey][]Lap)
+	compounds := make(map[stintKey]string)
+	lastLap := make(map[stintKey]int)
+	var keys []stintKey
+
+	for _, lap := range laps {
+		stint, ok := StintForLap(stints, lap)
+		key := stintKey{lap.DriverNumber, stint.Number}
+		if !ok {
+			// Without tyre data, a pit exit is the only sign of a new set
+			key.stint = -1
+		}
+		if _, seen := grouped[key]; !seen {
+			keys = append(keys, key)
+			compounds[key] = stint.Compound
+			lastLap[key] = stint.LapEnd
+		}
+		grouped[key] = append(grouped[key], lap)
+	}
+
+	var runs []LongRun
+	for _, key := range keys {
+		stintLaps := grouped[key]
+		sort.Slice(stintLaps, func(i, j int) bool { return stintLaps[i].Number < stintLaps[j].Number })
+
+		// Laps that could be part of a run, before checking their pace
+		usable := make([]bool, len(stintLaps))
+		var times []float64
+		for i, lap := range stintLaps {
+			inLap := key.stint >= 0 && lap.Number == lastLap[key]
+			usable[i] = !lap.PitOut && !inLap && i > 0 && IsGreenFlagLap(lap, neutralised)
+			if usable[i] {
+				times = append(times, lap.Duration)
+			}
+		}
+		if len(times) < LongRunMinLaps {
+			continue
+		}
+		limit := median(times) * longRunSpread
+
+		var current []Lap
+		flush := func() {
+			if len(current) >= LongRunMinLaps {
+				run := LongRun{DriverNumber: key.driver, Compound: compounds[key], Stint: key.stint, Laps: current}
+				for _, lap := range current {
+					run.Average += lap.Duration
+				}
+				run.Average /= float64(len(current))
+				runs = append(runs, run)
+			}
+			current = nil
+		}
+		for i, lap := range stintLaps {
+			consecutive := len(current) == 0 || lap.Number == current[len(current)-1].Number+1
+			if !usable[i] || lap.Duration > limit || !consecutive {
+				flush()
+			}
+			if usable[i] && lap.Duration <= limit {
+				current = append(current, lap)
+			}
+		}
+		flush()
+	}
+	return runs
+}
+
+// BestLongRuns keeps each driver's longest run, the quicker of equally long
+// ones, and ranks them by average pace. Short runs flatter a car, since fuel
+// and tyre wear barely build up.
+func BestLongRuns(runs []LongRun) []LongRun {
+	best := make(map[int]LongRun)
+	for _, run := range runs {
+		current, ok := best[run.DriverNumber]
+		if !ok || len(run.Laps) > len(current.Laps) ||
+			len(run.Laps) == len(current.Laps) && run.Average < current.Average {
+			best[run.DriverNumber] = run
+		}
+	}
+
+	ranked := make([]LongRun, 0, len(best))
+	for _, run := range best {
+		ranked = append(ranked, run)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Average != ranked[j].Average {
+			return ranked[i].Average < ranked[j].Average
+		}
+		return ranked[i].DriverNumber < ranked[j].DriverNumber
+	})
+	return ranked
+}
+
+// median returns the middle value of values, which must not be empty
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
@@ -0,0 +1,336 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// qualifyingBreak is the shortest pause in running taken to be the break
+// between two parts of qualifying
+const qualifyingBreak = 3 * time.Minute
+
+// QualifyingAdvance returns how many of a field of entrants go through to
+// the second and third parts of qualifying. Ten always reach the last part;
+// the rest are knocked out in equal numbers after each of the first two.
+func QualifyingAdvance(entrants int) (toSecond, toThird int) {
+	if entrants <= 10 {
+		return entrants, entrants
+	}
+	return 10 + (entrants-10)/2, 10
+}
+
+// IsSprintQualifying reports whether session sets the sprint grid. It was
+// called the Sprint Shootout in 2023.
+func IsSprintQualifying(session Session) bool {
+	return session.Name == "Sprint Qualifying" || session.Name == "Sprint Shootout"
+}
+
+// SegmentNames returns what the parts of a qualifying session are called
+func SegmentNames(session Session) [3]string {
+	if IsSprintQualifying(session) {
+		return [3]string{"SQ1", "SQ2", "SQ3"}
+	}
+	return [3]string{"Q1", "Q2", "Q3"}
+}
+
+// Best returns the driver's fastest lap of the session, or zero
+func (r QualifyingResult) Best() float64 {
+	best := 0.0
+	for _, t := range r.Times {
+		if t > 0 && (best == 0 || t < best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// Final returns the driver's best lap in the last part they took part in,
+// which is what decided their grid slot
+func (r QualifyingResult) Final() float64 {
+	if r.Segment < 1 || r.Segment > len(r.Times) {
+		return 0
+	}
+	return r.Times[r.Segment-1]
+}
+
+// TeammateDeltas compares each driver with their teammate in the last part
+// both set a time in. Positive deltas mean slower than the teammate; drivers
+// without a comparable teammate are left out.
+func TeammateDeltas(results []QualifyingResult) map[int]float64 {
+	byTeam := make(map[string][]QualifyingResult)
+	for _, result := range results {
+		if result.Team != "" {
+			byTeam[result.Team] = append(byTeam[result.Team], result)
+		}
+	}
+
+	deltas := make(map[int]float64)
+	for _, team := range byTeam {
+		if len(team) != 2 {
+			continue
+		}
+		a, b := team[0], team[1]
+		for segment := len(a.Times) - 1; segment >= 0; segment-- {
+			if a.Times[segment] > 0 && b.Times[segment] > 0 {
+				deltas[a.DriverNumber] = a.Times[segment] - b.Times[segment]
+				deltas[b.DriverNumber] = b.Times[segment] - a.Times[segment]
+				break
+			}
+		}
+	}
+	return deltas
+}
+
+// setSegments works out which part each driver reached from their grid
+// position, or from their times when they weren't classified
+func setSegments(results []QualifyingResult) {
+	// Single-session qualifying (before 2006 in this form) has no knockouts
+	knockout := false
+	for _, result := range results {
+		knockout = knockout || result.Times[1] > 0 || result.Times[2] > 0
+	}
+
+	toSecond, toThird := QualifyingAdvance(len(results))
+	for i := range results {
+		result := &results[i]
+		switch {
+		case !knockout:
+			result.Segment = 1
+		case result.Position > 0 && result.Position <= toThird:
+			result.Segment = 3
+		case result.Position > 0 && result.Position <= toSecond:
+			result.Segment = 2
+		case result.Position > 0:
+			result.Segment = 1
+		default:
+			result.Segment = 1
+			for segment, t := range result.Times {
+				if t > 0 {
+					result.Segment = segment + 1
+				}
+			}
+		}
+	}
+}
+
+// qualifyingFromLaps rebuilds a qualifying classification from lap times,
+// for when no official one has been published. The drivers knocked out of
+// each part stop running when it ends, so their last laps mark where one part
+// ends and the next begins. Deleted laps can't be told apart, so the result
+// is approximate.
+func qualifyingFromLaps(laps []Lap) []QualifyingResult {
+	lastStart := make(map[int]time.Time)
+	var starts []time.Time
+	for _, lap := range laps {
+		if lap.Start.IsZero() {
+			continue
+		}
+		starts = append(starts, lap.Start)
+		if lap.Start.After(lastStart[lap.DriverNumber]) {
+			lastStart[lap.DriverNumber] = lap.Start
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	drivers := make([]int, 0, len(lastStart))
+	for number := range lastStart {
+		drivers = append(drivers, number)
+	}
+	sort.Slice(drivers, func(i, j int) bool {
+		return lastStart[drivers[i]].Before(lastStart[drivers[j]])
+	})
+
+	toSecond, toThird := QualifyingAdvance(len(drivers))
+	knockouts := [2]int{len(drivers) - toSecond, toSecond - toThird}
+	segmentOf := make(map[int]int)
+	var ends []time.Time
+	out := 0
+	for part, count := range knockouts {
+		for _, number := range drivers[out : out+count] {
+			segmentOf[number] = part + 1
+		}
+		out += count
+		if count > 0 {
+			ends = append(ends, runningUntilBreak(starts, lastStart[drivers[out-1]]))
+		}
+	}
+
+	// Everyone not knocked out ran in the last part found; with ten cars or
+	// fewer nobody is, and the whole session counts as one part
+	byDriver := make(map[int]*QualifyingResult)
+	for _, number := range drivers {
+		segment := segmentOf[number]
+		if segment == 0 {
+			segment = len(ends) + 1
+		}
+		byDriver[number] = &QualifyingResult{DriverNumber: number, Segment: segment}
+	}
+
+	for _, lap := range laps {
+		result, ok := byDriver[lap.DriverNumber]
+		if !ok {
+			continue
+		}
+		result.Laps++
+		if lap.Duration <= 0 || lap.PitOut {
+			continue
+		}
+
+		part := 0
+		for part < len(ends) && lap.Start.After(ends[part]) {
+			part++
+		}
+		if part >= len(result.Times) {
+			part = len(result.Times) - 1
+		}
+		if result.Times[part] == 0 || lap.Duration < result.Times[part] {
+			result.Times[part] = lap.Duration
+		}
+	}
+
+	results := make([]QualifyingResult, 0, len(byDriver))
+	for _, result := range byDriver {
+		results = append(results, *result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Segment != b.Segment {
+			return a.Segment > b.Segment
+		}
+		if (a.Final() == 0) != (b.Final() == 0) {
+			return b.Final() == 0
+		}
+		if a.Final() != b.Final() {
+			return a.Final() < b.Final()
+		}
+		return a.DriverNumber < b.DriverNumber
+	})
+	for i := range results {
+		results[i].Position = i + 1
+	}
+	return results
+}
+
+// runningUntilBreak follows lap starts on from t until the first pause of at
+// least qualifyingBreak, and returns the last start before it
+func runningUntilBreak(starts []time.Time, t time.Time) time.Time {
+	i := sort.Search(len(starts), func(i int) bool { return starts[i].After(t) })
+	for ; i < len(starts); i++ {
+		if starts[i].Sub(t) >= qualifyingBreak {
+			break
+		}
+		t = starts[i]
+	}
+	return t
+}
+
+// FormatLapTime formats seconds as a lap time, e.g. "1:26.204"
+func FormatLapTime(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+	millis := int64(seconds*1000 + 0.5)
+	minutes := millis / 60000
+	millis -= minutes * 60000
+	if minutes == 0 {
+		return fmt.Sprintf("%d.%03d", millis/1000, millis%1000)
+	}
+	return fmt.Sprintf("%d:%02d.%03d", minutes, millis/1000, millis%1000)
+}
+
+// ParseLapTime reads a lap time such as "1:26.204" or "58.9" into seconds
+func ParseLapTime(text string) (float64, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, false
+	}
+
+	minutes := 0
+	if m, rest, ok := strings.Cut(text, ":"); ok {
+		if _, err := fmt.Sscanf(m, "%d", &minutes); err != nil {
+			return 0, false
+		}
+		text = rest
+	}
+	var seconds float64
+	if _, err := fmt.Sscanf(text, "%g", &seconds); err != nil {
+		return 0, false
+	}
+	return float64(minutes)*60 + seconds, true
+}
+
+// GetWeekendSessions returns the season's sessions of one type, such as
+// "Qualifying" or "Practice"
+func (ds *DataService) GetWeekendSessions(sessionType string) ([]Session, error) {
+	return ds.GetWeekendSessionsContext(context.Background(), sessionType)
+}
+
+// GetWeekendSessionsContext is like GetWeekendSessions but takes a context for cancellation
+func (ds *DataService) GetWeekendSessionsContext(ctx context.Context, sessionType string) ([]Session, error) {
+	source, ok := ds.source.(WeekendSource)
+	if !ok {
+		return nil, fmt.Errorf("%s has no %s sessions", ds.source.Name(), strings.ToLower(sessionType))
+	}
+	return source.WeekendSessions(ctx, ds.season, sessionType)
+}
+
+// GetQualifying returns a qualifying session's results in grid order
+func (ds *DataService) GetQualifying(session Session) ([]QualifyingResult, error) {
+	return ds.GetQualifyingContext(context.Background(), session)
+}
+
+// GetQualifyingContext is like GetQualifying but takes a context for cancellation
+func (ds *DataService) GetQualifyingContext(ctx context.Context, session Session) ([]QualifyingResult, error) {
+	source, ok := ds.source.(QualifyingSource)
+	if !ok {
+		return nil, fmt.Errorf("%s has no qualifying results", ds.source.Name())
+	}
+	return source.Qualifying(ctx, session)
+}
+
+// fillPolePositions sets the pole sitter of every race whose qualifying has
+// ended, for sources whose schedule doesn't include them. A race the source
+// has no qualifying results for keeps an empty PolePosition; other failures
+// are returned.
+func (ds *DataService) fillPolePositions(ctx context.Context, races []Race) error {
+	if _, ok := ds.source.(WeekendSource); !ok {
+		return nil
+	}
+	if _, ok := ds.source.(QualifyingSource); !ok {
+		return nil
+	}
+	missing := make(map[int]*Race)
+	for i := range races {
+		if races[i].Round > 0 && races[i].PolePosition == "" && races[i].Status != "upcoming" {
+			missing[races[i].Round] = &races[i]
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sessions, err := ds.GetWeekendSessionsContext(ctx, "Qualifying")
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		race, ok := missing[session.Round]
+		if !ok || session.Name != "Qualifying" || !session.HasEnded() {
+			continue
+		}
+		results, err := ds.GetQualifyingContext(ctx, session)
+		if err != nil {
+			if isNoData(err) {
+				continue
+			}
+			return fmt.Errorf("failed to fetch qualifying for %s: %w", race.Name, err)
+		}
+		if len(results) > 0 && results[0].Position == 1 {
+			race.PolePosition = results[0].Driver
+		}
+	}
+	return nil
+}
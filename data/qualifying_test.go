@@ -0,0 +1,134 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+// qualifyingPart is one part of a synthetic qualifying session
+type qualifyingPart struct {
+	// start and end are minutes from the start of the session
+	start, end float64
+	// drivers are the car numbers still running
+	drivers []int
+	// lapTime gives each driver's best lap of the part in seconds
+	lapTime func(number int) float64
+	// best is the run on which drivers set that lap
+	best int
+	// redFlag stops running from and to these minutes, when set
+	redFlag [2]float64
+}
+
+// qualifyingLaps simulates the laps of a qualifying session. Every driver
+// still running sets a lap every 100 seconds, a few seconds apart from each
+// other; each run opens with an out lap.
+func qualifyingLaps(parts []qualifyingPart) []Lap {
+	session := time.Date(2025, 5, 3, 20, 0, 0, 0, time.UTC)
+	var laps []Lap
+	for _, part := range parts {
+		for _, number := range part.drivers {
+			lapNumber := 0
+			for run := 0; ; run++ {
+				minutes := part.start + float64(run*100+number*3)/60
+				if minutes >= part.end {
+					break
+				}
+				if part.redFlag[1] > 0 && minutes >= part.redFlag[0] && minutes < part.redFlag[1] {
+					continue
+				}
+
+				lapNumber++
+				lap := Lap{
+					DriverNumber: number,
+					Number:       lapNumber,
+					Start:        session.Add(time.Duration(minutes * float64(time.Minute))),
+					Duration:     part.lapTime(number) + 1.5,
+				}
+				switch {
+				case run == 0:
+					lap.PitOut = true
+					lap.Duration = 0
+				case run == part.best:
+					lap.Duration = part.lapTime(number)
+				}
+				laps = append(laps, lap)
+			}
+		}
+	}
+	return laps
+}
+
+// carNumbers returns 1 to n
+func carNumbers(n int) []int {
+	numbers := make([]int, n)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	return numbers
+}
+
+func TestQualifyingFromLaps(t *testing.T) {
+	// In a full field the lower the number, the quicker through Q1 and Q2;
+	// Q3 turns the top ten around
+	q1 := func(number int) float64 { return 80 + float64(number)/10 }
+	q2 := func(number int) float64 { return 79.5 + float64(number)/10 }
+	q3 := func(number int) float64 { return 79 + float64(11-number)/10 }
+
+	full := qualifyingLaps([]qualifyingPart{
+		{start: 0, end: 18, drivers: carNumbers(20), lapTime: q1, best: 3},
+		// A red flag stops Q2 for six minutes, more than qualifyingBreak;
+		// the best laps come after it
+		{start: 25, end: 40, drivers: carNumbers(15), lapTime: q2, best: 7, redFlag: [2]float64{28, 34}},
+		{start: 47, end: 59, drivers: carNumbers(10), lapTime: q3, best: 5},
+	})
+	results := qualifyingFromLaps(full)
+	if len(results) != 20 {
+		t.Fatalf("got %d drivers, want 20", len(results))
+	}
+
+	want := []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	for i, result := range results {
+		number := result.DriverNumber
+		if number != want[i] || result.Position != i+1 {
+			t.Fatalf("P%d = #%d at position %d, want #%d", i+1, number, result.Position, want[i])
+		}
+
+		segment := 1
+		if number <= 10 {
+			segment = 3
+		} else if number <= 15 {
+			segment = 2
+		}
+		if result.Segment != segment {
+			t.Errorf("#%d reached Q%d, want Q%d", number, result.Segment, segment)
+		}
+
+		times := [3]float64{q1(number)}
+		if segment >= 2 {
+			times[1] = q2(number)
+		}
+		if segment == 3 {
+			times[2] = q3(number)
+		}
+		if result.Times != times {
+			t.Errorf("#%d times = %v, want %v", number, result.Times, times)
+		}
+	}
+
+	// Ten cars or fewer have nobody to knock out: one part, ordered on it
+	for _, size := range []int{10, 6} {
+		laps := qualifyingLaps([]qualifyingPart{
+			{start: 0, end: 18, drivers: carNumbers(size), lapTime: q3, best: 4},
+		})
+		results := qualifyingFromLaps(laps)
+		if len(results) != size {
+			t.Fatalf("%d cars: got %d drivers", size, len(results))
+		}
+		for i, result := range results {
+			if number := size - i; result.DriverNumber != number || result.Position != i+1 ||
+				result.Segment != 1 || result.Times != [3]float64{q3(number)} {
+				t.Errorf("%d cars: P%d = %+v, want #%d in Q1 on %.3f", size, i+1, result, number, q3(number))
+			}
+		}
+	}
+}
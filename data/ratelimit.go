@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a classic token bucket: it holds up to capacity tokens and
+// refills them evenly over the refill period.
+type TokenBucket struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	perSec   float64
+	last     time.Time
+}
+
+// NewTokenBucket allows capacity requests per period, starting full
+func NewTokenBucket(capacity int, period time.Duration) *TokenBucket {
+	return &TokenBucket{
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		perSec:   float64(capacity) / period.Seconds(),
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it. Tokens may go negative so concurrent callers queue up fairly.
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.perSec
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.perSec * float64(time.Second))
+}
+
+// RateLimiter enforces several token buckets at once, e.g. per second and per minute
+type RateLimiter struct {
+	buckets []*TokenBucket
+}
+
+// NewRateLimiter combines the given buckets; a request waits for all of them
+func NewRateLimiter(buckets ...*TokenBucket) *RateLimiter {
+	return &RateLimiter{buckets: buckets}
+}
+
+// NewOpenF1RateLimiter matches OpenF1's published limits for unauthenticated
+// use: 3 requests per second and 30 requests per minute.
+func NewOpenF1RateLimiter() *RateLimiter {
+	return NewRateLimiter(
+		NewTokenBucket(3, time.Second),
+		NewTokenBucket(30, time.Minute),
+	)
+}
+
+// Reserve takes a token from every bucket and returns the longest wait
+func (l *RateLimiter) Reserve() time.Duration {
+	var wait time.Duration
+	for _, bucket := range l.buckets {
+		if d := bucket.reserve(); d > wait {
+			wait = d
+		}
+	}
+	return wait
+}
+
+// Wait blocks until a request is allowed
+func (l *RateLimiter) Wait() {
+	_ = l.WaitContext(context.Background())
+}
+
+// WaitContext blocks until a request is allowed or ctx is done
+func (l *RateLimiter) WaitContext(ctx context.Context) error {
+	return sleepContext(ctx, l.Reserve())
+}
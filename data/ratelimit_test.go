@@ -0,0 +1,104 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketAllowsBurst(t *testing.T) {
+	bucket := NewTokenBucket(3, time.Second)
+	for i := 0; i < 3; i++ {
+		if wait := bucket.reserve(); wait != 0 {
+			t.Fatalf("request %d waits %v, want none within the burst", i+1, wait)
+		}
+	}
+
+	// The fourth request needs a third of a second's refill
+	if wait := bucket.reserve(); wait < 300*time.Millisecond || wait > 334*time.Millisecond {
+		t.Errorf("request 4 waits %v, want about 333ms", wait)
+	}
+	// and the fifth queues up behind it
+	if wait := bucket.reserve(); wait < 633*time.Millisecond || wait > 667*time.Millisecond {
+		t.Errorf("request 5 waits %v, want about 667ms", wait)
+	}
+}
+
+func TestTokenBucketRefills(t *testing.T) {
+	bucket := NewTokenBucket(2, time.Second)
+	bucket.reserve()
+	bucket.reserve()
+
+	// Half a second later one token is back; refill never exceeds capacity
+	bucket.last = bucket.last.Add(-500 * time.Millisecond)
+	if wait := bucket.reserve(); wait != 0 {
+		t.Errorf("after refill waits %v, want none", wait)
+	}
+
+	bucket.last = bucket.last.Add(-time.Hour)
+	for i := 0; i < 2; i++ {
+		if wait := bucket.reserve(); wait != 0 {
+			t.Errorf("request %d after a long pause waits %v, want none", i+1, wait)
+		}
+	}
+	if wait := bucket.reserve(); wait == 0 {
+		t.Error("bucket refilled beyond its capacity")
+	}
+}
+
+func TestRateLimiterWaitsForSlowestBucket(t *testing.T) {
+	limiter := NewRateLimiter(
+		NewTokenBucket(10, time.Second),
+		NewTokenBucket(1, time.Minute),
+	)
+	if wait := limiter.Reserve(); wait != 0 {
+		t.Fatalf("first request waits %v, want none", wait)
+	}
+	if wait := limiter.Reserve(); wait < 59*time.Second {
+		t.Errorf("second request waits %v, want the per-minute bucket's ~60s", wait)
+	}
+}
+
+func TestRateLimiterWaitContextCancelled(t *testing.T) {
+	limiter := NewRateLimiter(NewTokenBucket(1, time.Minute))
+	limiter.Reserve()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.WaitContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitContext = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientPacesRequests(t *testing.T) {
+	var arrivals []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrivals = append(arrivals, time.Now())
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv)
+	client.Limiter = NewRateLimiter(NewTokenBucket(2, 200*time.Millisecond))
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.makeRequest(context.Background(), "sessions"); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+
+	// Two go straight out, then one every 100ms
+	if len(arrivals) != 4 {
+		t.Fatalf("server saw %d requests, want 4", len(arrivals))
+	}
+	if burst := arrivals[1].Sub(start); burst > 50*time.Millisecond {
+		t.Errorf("burst took %v, want no wait", burst)
+	}
+	if paced := arrivals[3].Sub(start); paced < 190*time.Millisecond {
+		t.Errorf("fourth request after %v, want at least 200ms", paced)
+	}
+}
@@ -0,0 +1,395 @@
+package data
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Regulations describes how points were awarded in a range of seasons.
+// Dropped-score rules from before 1991 are not modelled; historical
+// standings come from the source's official tables instead.
+type Regulations struct {
+	// FromSeason is the first season these rules applied to
+	FromSeason int
+	// RacePoints are the points for P1, P2, ... in a full-distance race
+	RacePoints []float64
+	// SprintPoints are the points for P1, P2, ... in a sprint; nil if sprints score nothing
+	SprintPoints []float64
+	// FastestLapPoints is the bonus for setting the race's fastest lap
+	FastestLapPoints float64
+	// FastestLapTopN limits the bonus to drivers finishing in the top N; 0 means anyone
+	FastestLapTopN int
+	// HalfPointsBelow halves race points when less than this share of the
+	// distance was completed (the rule used until 2021)
+	HalfPointsBelow float64
+	// ShortRaceScales replace RacePoints for shortened races (the rule from 2022)
+	ShortRaceScales []ShortRaceScale
+	// DoublePointsRounds lists rounds that awarded double points
+	DoublePointsRounds []int
+}
+
+// ShortRaceScale is the points scale for races that completed less than MaxDistance
+type ShortRaceScale struct {
+	MaxDistance float64
+	Points      []float64
+}
+
+// regulationsHistory lists every change to the points system, oldest first.
+// Each entry applies until the next one's FromSeason.
+var regulationsHistory = []Regulations{
+	{
+		FromSeason:       1950,
+		RacePoints:       []float64{8, 6, 4, 3, 2},
+		FastestLapPoints: 1,
+		HalfPointsBelow:  0.75,
+	},
+	{
+		FromSeason:      1960,
+		RacePoints:      []float64{8, 6, 4, 3, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		FromSeason:      1961,
+		RacePoints:      []float64{9, 6, 4, 3, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		FromSeason:      1991,
+		RacePoints:      []float64{10, 6, 4, 3, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		FromSeason:      2003,
+		RacePoints:      []float64{10, 8, 6, 5, 4, 3, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		FromSeason:      2010,
+		RacePoints:      []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		// Double points at the Abu Dhabi finale
+		FromSeason:         2014,
+		RacePoints:         []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		HalfPointsBelow:    0.75,
+		DoublePointsRounds: []int{19},
+	},
+	{
+		FromSeason:      2015,
+		RacePoints:      []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		HalfPointsBelow: 0.75,
+	},
+	{
+		FromSeason:       2019,
+		RacePoints:       []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		FastestLapPoints: 1,
+		FastestLapTopN:   10,
+		HalfPointsBelow:  0.75,
+	},
+	{
+		// Sprint qualifying: points for the top three only
+		FromSeason:       2021,
+		RacePoints:       []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		SprintPoints:     []float64{3, 2, 1},
+		FastestLapPoints: 1,
+		FastestLapTopN:   10,
+		HalfPointsBelow:  0.75,
+	},
+	{
+		FromSeason:       2022,
+		RacePoints:       []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		SprintPoints:     []float64{8, 7, 6, 5, 4, 3, 2, 1},
+		FastestLapPoints: 1,
+		FastestLapTopN:   10,
+		ShortRaceScales: []ShortRaceScale{
+			{MaxDistance: 0.25, Points: []float64{5, 4, 3, 2, 1}},
+			{MaxDistance: 0.50, Points: []float64{13, 10, 8, 6, 5, 4, 3, 2, 1}},
+			{MaxDistance: 0.75, Points: []float64{19, 14, 12, 9, 8, 6, 5, 3, 2, 1}},
+		},
+	},
+	{
+		FromSeason:       2023,
+		RacePoints:       []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		SprintPoints:     []float64{8, 7, 6, 5, 4, 3, 2, 1},
+		FastestLapPoints: 1,
+		FastestLapTopN:   10,
+		ShortRaceScales: []ShortRaceScale{
+			{MaxDistance: 0.25, Points: []float64{6, 4, 3, 2, 1}},
+			{MaxDistance: 0.50, Points: []float64{13, 10, 8, 6, 5, 4, 3, 2, 1}},
+			{MaxDistance: 0.75, Points: []float64{19, 14, 12, 9, 8, 6, 5, 3, 2, 1}},
+		},
+	},
+	{
+		// The fastest lap bonus was dropped
+		FromSeason:   2025,
+		RacePoints:   []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		SprintPoints: []float64{8, 7, 6, 5, 4, 3, 2, 1},
+		ShortRaceScales: []ShortRaceScale{
+			{MaxDistance: 0.25, Points: []float64{6, 4, 3, 2, 1}},
+			{MaxDistance: 0.50, Points: []float64{13, 10, 8, 6, 5, 4, 3, 2, 1}},
+			{MaxDistance: 0.75, Points: []float64{19, 14, 12, 9, 8, 6, 5, 3, 2, 1}},
+		},
+	},
+}
+
+// seasonRound identifies a round of a championship
+type seasonRound struct {
+	Season int
+	Round  int
+}
+
+// shortenedRaces records races that were stopped early, as the share of the
+// scheduled distance completed
+var shortenedRaces = map[seasonRound]float64{
+	{1975, 4}:  29.0 / 75, // Spain
+	{1975, 12}: 29.0 / 54, // Austria
+	{1984, 6}:  31.0 / 77, // Monaco
+	{1991, 16}: 14.0 / 81, // Australia
+	{2009, 2}:  31.0 / 56, // Malaysia
+	{2021, 12}: 1.0 / 44,  // Belgium
+}
+
+// RegulationsFor returns the points rules in force for season
+func RegulationsFor(season int) Regulations {
+	i := sort.Search(len(regulationsHistory), func(i int) bool {
+		return regulationsHistory[i].FromSeason > season
+	})
+	if i == 0 {
+		return regulationsHistory[0]
+	}
+	return regulationsHistory[i-1]
+}
+
+// Season returns the championship year a session belongs to
+func (s Session) Season() int {
+	if s.Year != 0 {
+		return s.Year
+	}
+	return s.DateStart.Year()
+}
+
+// completedDistance returns the share of the race distance completed,
+// or 1 when the race ran full distance
+func completedDistance(session Session) float64 {
+	if distance, ok := shortenedRaces[seasonRound{session.Season(), session.Round}]; ok {
+		return distance
+	}
+	return 1
+}
+
+// PointsScale returns the points for P1, P2, ... in the given session
+func (r Regulations) PointsScale(session Session) []float64 {
+	if session.Name == "Sprint" {
+		return r.SprintPoints
+	}
+
+	scale := r.RacePoints
+	distance := completedDistance(session)
+
+	multiplier := 1.0
+	for _, round := range r.DoublePointsRounds {
+		if round == session.Round {
+			multiplier = 2
+		}
+	}
+	if distance < r.HalfPointsBelow {
+		multiplier /= 2
+	}
+	for _, short := range r.ShortRaceScales {
+		if distance < short.MaxDistance {
+			scale = short.Points
+			break
+		}
+	}
+
+	if multiplier == 1 {
+		return scale
+	}
+	scaled := make([]float64, len(scale))
+	for i, points := range scale {
+		scaled[i] = points * multiplier
+	}
+	return scaled
+}
+
+// ScoredResult is a classification line with the points it earned
+type ScoredResult struct {
+	SessionResult
+	// ClassifiedPosition is the position points were awarded for, after
+	// stewards' decisions; Position keeps the original finishing order
+	ClassifiedPosition int
+	Disqualified       bool
+	// Adjusted is true when ClassifiedPosition differs from Position
+	Adjusted        bool
+	Points          float64
+	FastestLapBonus bool
+	// Win is true for the winner of a main race; sprint wins don't count
+	Win bool
+	// Corrections are the ledger entries applied to this driver
+	Corrections []Correction
+}
+
+// ScoreSession applies the season's regulations and the stewards' decisions
+// recorded in ledger to a session classification. Disqualified drivers are
+// dropped and the rest of the field reflowed, so the ledger only needs to list
+// who was excluded; time penalties are added to the finishers' times before
+// the reflow. Results come back ordered by classified position, with
+// disqualified drivers last.
+func ScoreSession(session Session, results []SessionResult, ledger *Ledger) []ScoredResult {
+	regs := RegulationsFor(session.Season())
+	scale := regs.PointsScale(session)
+
+	disqualified := make(map[int]bool)
+	reinstated := make(map[int]bool)
+	adjustments := make(map[int]int)
+	deductions := make(map[int]float64)
+	penalties := make(map[int]float64)
+	applied := make(map[int][]Correction)
+	for _, correction := range ledger.ForSession(session) {
+		driverNumber := correction.DriverNumber
+		switch correction.Type {
+		case CorrectionDisqualification:
+			disqualified[driverNumber] = true
+		case CorrectionReinstatement:
+			delete(disqualified, driverNumber)
+			reinstated[driverNumber] = true
+		case CorrectionPointsDeduction:
+			deductions[driverNumber] += correction.Points
+		case CorrectionTimePenalty:
+			penalties[driverNumber] += correction.Seconds
+		}
+		if correction.Position > 0 {
+			adjustments[driverNumber] = correction.Position
+		}
+		applied[driverNumber] = append(applied[driverNumber], correction)
+	}
+
+	// Drivers with a position set by the stewards keep it; everyone else still
+	// classified moves up into the free places in their original order
+	order := make([]SessionResult, len(results))
+	copy(order, results)
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Position < order[j].Position
+	})
+	if len(penalties) > 0 {
+		applyTimePenalties(order, penalties)
+	}
+
+	pinned := make(map[int]bool)
+	for driverNumber, position := range adjustments {
+		if !disqualified[driverNumber] {
+			pinned[position] = true
+		}
+	}
+
+	scored := make([]ScoredResult, 0, len(results))
+	nextPosition := 1
+	for _, result := range order {
+		entry := ScoredResult{
+			SessionResult:      result,
+			ClassifiedPosition: result.Position,
+			Corrections:        applied[result.DriverNumber],
+		}
+
+		if disqualified[result.DriverNumber] || (result.DSQ && !reinstated[result.DriverNumber]) {
+			entry.Disqualified = true
+			scored = append(scored, entry)
+			continue
+		}
+
+		if position, wasSet := adjustments[result.DriverNumber]; wasSet {
+			entry.ClassifiedPosition = position
+		} else if result.Position > 0 {
+			for pinned[nextPosition] {
+				nextPosition++
+			}
+			entry.ClassifiedPosition = nextPosition
+			nextPosition++
+		}
+		entry.Adjusted = entry.ClassifiedPosition != result.Position
+
+		position := entry.ClassifiedPosition
+		if position >= 1 && position <= len(scale) {
+			entry.Points = scale[position-1]
+		}
+
+		if result.FastestLap && session.Name == "Race" && regs.FastestLapPoints > 0 &&
+			(regs.FastestLapTopN == 0 || (position >= 1 && position <= regs.FastestLapTopN)) {
+			entry.Points += regs.FastestLapPoints
+			entry.FastestLapBonus = true
+		}
+		entry.Points -= deductions[result.DriverNumber]
+
+		entry.Win = position == 1 && session.Name == "Race"
+		scored = append(scored, entry)
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Disqualified != scored[j].Disqualified {
+			return !scored[i].Disqualified
+		}
+		// Drivers without a position go after everyone who has one
+		if (scored[i].ClassifiedPosition == 0) != (scored[j].ClassifiedPosition == 0) {
+			return scored[j].ClassifiedPosition == 0
+		}
+		return scored[i].ClassifiedPosition < scored[j].ClassifiedPosition
+	})
+
+	return scored
+}
+
+// applyTimePenalties adds each driver's penalty seconds to their race time
+// and reorders the finishers whose time is known on it, so the reflow below
+// derives the positions. Drivers a lap or more down and non-finishers have no
+// time to add to and keep their places behind.
+func applyTimePenalties(order []SessionResult, penalties map[int]float64) {
+	var slots []int
+	var timed []SessionResult
+	for i, result := range order {
+		if _, ok := raceTime(result); ok {
+			slots = append(slots, i)
+			timed = append(timed, result)
+		}
+	}
+
+	penalised := func(result SessionResult) float64 {
+		gap, _ := raceTime(result)
+		return gap + penalties[result.DriverNumber]
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return penalised(timed[i]) < penalised(timed[j])
+	})
+	for i, slot := range slots {
+		order[slot] = timed[i]
+	}
+}
+
+// raceTime returns a finisher's time behind the winner, and false when it
+// isn't known, as for lapped drivers and non-finishers
+func raceTime(result SessionResult) (float64, bool) {
+	switch {
+	case result.Position <= 0 || result.DNF || result.DNS || result.DSQ:
+		return 0, false
+	case result.Position == 1:
+		return 0, true
+	case result.GapSeconds > 0:
+		return result.GapSeconds, true
+	}
+	return 0, false
+}
+
+// FormatPoints renders a points total without trailing zeros ("25", "12.5")
+func FormatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// FormatPointsScale renders a scale as "25-18-15-..."
+func FormatPointsScale(scale []float64) string {
+	parts := make([]string, len(scale))
+	for i, points := range scale {
+		parts[i] = FormatPoints(points)
+	}
+	return strings.Join(parts, "-")
+}
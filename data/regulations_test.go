@@ -0,0 +1,238 @@
+package data
+
+import "testing"
+
+// scoredOrder returns the driver numbers in classified order, with the
+// classified position of each
+func scoredOrder(scored []ScoredResult) ([]int, []int) {
+	var numbers, positions []int
+	for _, result := range scored {
+		numbers = append(numbers, result.DriverNumber)
+		positions = append(positions, result.ClassifiedPosition)
+	}
+	return numbers, positions
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScoreSessionTimePenalty(t *testing.T) {
+	sprint := Session{Key: 10028, Name: "Sprint", Location: "Miami", Year: 2025}
+	results := []SessionResult{
+		{DriverNumber: 4, Position: 1},
+		{DriverNumber: 81, Position: 2, GapSeconds: 0.7},
+		{DriverNumber: 1, Position: 3, GapSeconds: 2.1},
+		{DriverNumber: 44, Position: 4, GapSeconds: 3.0},
+		{DriverNumber: 23, Position: 5, GapSeconds: 9.5},
+		{DriverNumber: 18, Position: 6, GapSeconds: 14.2},
+		{DriverNumber: 22, Position: 7, Gap: "+1 LAP"},
+		{DriverNumber: 14, Position: 8, DNF: true},
+	}
+	ledger := &Ledger{Corrections: []Correction{{
+		Season: 2025, Location: "Miami", Session: "Sprint",
+		Type: CorrectionTimePenalty, DriverNumber: 1, Seconds: 10,
+	}}}
+
+	scored := ScoreSession(sprint, results, ledger)
+	numbers, positions := scoredOrder(scored)
+	// 2.1s + 10s puts #1 behind #23 (9.5s) and ahead of #18 (14.2s); the
+	// lapped driver and the retirement have no time and stay behind
+	if want := []int{4, 81, 44, 23, 1, 18, 22, 14}; !equalInts(numbers, want) {
+		t.Fatalf("order = %v, want %v", numbers, want)
+	}
+	if want := []int{1, 2, 3, 4, 5, 6, 7, 8}; !equalInts(positions, want) {
+		t.Errorf("positions = %v, want %v", positions, want)
+	}
+	if penalised := scored[4]; !penalised.Adjusted || penalised.Points != 4 || len(penalised.Corrections) != 1 {
+		t.Errorf("penalised driver = %+v, want P5 with 4 points", penalised)
+	}
+	if promoted := scored[2]; !promoted.Adjusted || promoted.Points != 6 {
+		t.Errorf("promoted driver = %+v, want P3 with 6 points", promoted)
+	}
+}
+
+func TestScoreSessionDisqualificationReflow(t *testing.T) {
+	race := Session{Key: 9998, Name: "Race", Location: "Shanghai", Year: 2025}
+	// Twelve finishers, #1 to #12 in order
+	var results []SessionResult
+	for i := 1; i <= 12; i++ {
+		results = append(results, SessionResult{DriverNumber: i, Position: i})
+	}
+	dsq := func(numbers ...int) *Ledger {
+		ledger := &Ledger{}
+		for _, number := range numbers {
+			ledger.Corrections = append(ledger.Corrections, Correction{
+				Season: 2025, Location: "Shanghai", Session: "Race",
+				Type: CorrectionDisqualification, DriverNumber: number,
+			})
+		}
+		return ledger
+	}
+
+	tests := []struct {
+		name   string
+		ledger *Ledger
+		// order is the classification afterwards, disqualified drivers last
+		order []int
+		// points are what the classified drivers score, in order
+		points []float64
+	}{
+		{
+			name:   "in the points",
+			ledger: dsq(2),
+			order:  []int{1, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 2},
+			points: []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1, 0},
+		},
+		{
+			name:   "outside the points",
+			ledger: dsq(12),
+			order:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			points: []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1, 0},
+		},
+		{
+			name:   "several in one session",
+			ledger: dsq(10, 4, 1),
+			order:  []int{2, 3, 5, 6, 7, 8, 9, 11, 12, 1, 4, 10},
+			points: []float64{25, 18, 15, 12, 10, 8, 6, 4, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scored := ScoreSession(race, results, tt.ledger)
+			numbers, _ := scoredOrder(scored)
+			if !equalInts(numbers, tt.order) {
+				t.Fatalf("order = %v, want %v", numbers, tt.order)
+			}
+
+			excluded := len(tt.ledger.Corrections)
+			for i, result := range scored {
+				if i >= len(scored)-excluded {
+					if !result.Disqualified || result.Points != 0 {
+						t.Errorf("#%d = %+v, want disqualified without points", result.DriverNumber, result)
+					}
+					continue
+				}
+				if result.ClassifiedPosition != i+1 {
+					t.Errorf("#%d classified P%d, want P%d", result.DriverNumber, result.ClassifiedPosition, i+1)
+				}
+				if want := result.Position != i+1; result.Adjusted != want {
+					t.Errorf("#%d Adjusted = %v, want %v", result.DriverNumber, result.Adjusted, want)
+				}
+				if i < len(tt.points) && result.Points != tt.points[i] {
+					t.Errorf("#%d scored %v, want %v", result.DriverNumber, result.Points, tt.points[i])
+				}
+			}
+			if winner := scored[0]; !winner.Win {
+				t.Errorf("#%d classified first isn't the winner", winner.DriverNumber)
+			}
+		})
+	}
+}
+
+func equalScales(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPointsScaleByEra(t *testing.T) {
+	// Stopped races from after the recorded ones, to exercise the 2022 scales
+	shortenedRaces[seasonRound{2022, 99}] = 0.2
+	shortenedRaces[seasonRound{2023, 98}] = 0.2
+	shortenedRaces[seasonRound{2023, 99}] = 0.6
+	t.Cleanup(func() {
+		delete(shortenedRaces, seasonRound{2022, 99})
+		delete(shortenedRaces, seasonRound{2023, 98})
+		delete(shortenedRaces, seasonRound{2023, 99})
+	})
+
+	tests := []struct {
+		name    string
+		season  int
+		round   int
+		session string
+		want    []float64
+	}{
+		{"1950 race", 1950, 1, "Race", []float64{8, 6, 4, 3, 2}},
+		{"1960 race", 1960, 1, "Race", []float64{8, 6, 4, 3, 2, 1}},
+		{"1961 race", 1961, 1, "Race", []float64{9, 6, 4, 3, 2, 1}},
+		{"1975 Spain, half points", 1975, 4, "Race", []float64{4.5, 3, 2, 1.5, 1, 0.5}},
+		{"1991 race", 1991, 1, "Race", []float64{10, 6, 4, 3, 2, 1}},
+		{"1991 Australia, half points", 1991, 16, "Race", []float64{5, 3, 2, 1.5, 1, 0.5}},
+		{"2003 race", 2003, 1, "Race", []float64{10, 8, 6, 5, 4, 3, 2, 1}},
+		{"2009 Malaysia, half points", 2009, 2, "Race", []float64{5, 4, 3, 2.5, 2, 1.5, 1, 0.5}},
+		{"2010 race", 2010, 1, "Race", []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1}},
+		{"2014 Abu Dhabi, double points", 2014, 19, "Race", []float64{50, 36, 30, 24, 20, 16, 12, 8, 4, 2}},
+		{"2014 race", 2014, 18, "Race", []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1}},
+		{"2020 sprint", 2020, 1, "Sprint", nil},
+		{"2021 sprint", 2021, 10, "Sprint", []float64{3, 2, 1}},
+		{"2021 Belgium, half points", 2021, 12, "Race", []float64{12.5, 9, 7.5, 6, 5, 4, 3, 2, 1, 0.5}},
+		{"2022 sprint", 2022, 4, "Sprint", []float64{8, 7, 6, 5, 4, 3, 2, 1}},
+		{"2022 under a quarter", 2022, 99, "Race", []float64{5, 4, 3, 2, 1}},
+		{"2023 under a quarter", 2023, 98, "Race", []float64{6, 4, 3, 2, 1}},
+		{"2023 under three quarters", 2023, 99, "Race", []float64{19, 14, 12, 9, 8, 6, 5, 3, 2, 1}},
+		{"2025 race", 2025, 1, "Race", []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1}},
+		{"2025 sprint", 2025, 6, "Sprint", []float64{8, 7, 6, 5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := Session{Name: tt.session, Year: tt.season, Round: tt.round}
+			got := RegulationsFor(tt.season).PointsScale(session)
+			if !equalScales(got, tt.want) {
+				t.Errorf("PointsScale = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFastestLapPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		season  int
+		session string
+		// position is where the driver with the fastest lap finished
+		position int
+		points   float64
+		bonus    bool
+	}{
+		{"1950, anyone scores", 1950, "Race", 7, 1, true},
+		{"1960, no bonus", 1960, "Race", 1, 8, false},
+		{"2019, inside the top 10", 2019, "Race", 5, 11, true},
+		{"2019, outside the top 10", 2019, "Race", 11, 0, false},
+		{"2023, sprints don't count", 2023, "Sprint", 1, 8, false},
+		{"2025, no bonus", 2025, "Race", 1, 25, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := Session{Name: tt.session, Year: tt.season, Round: 1}
+			var results []SessionResult
+			for i := 1; i <= 12; i++ {
+				results = append(results, SessionResult{DriverNumber: i, Position: i, FastestLap: i == tt.position})
+			}
+
+			got := ScoreSession(session, results, nil)[tt.position-1]
+			if got.Points != tt.points || got.FastestLapBonus != tt.bonus {
+				t.Errorf("P%d with the fastest lap scored %v (bonus %v), want %v (bonus %v)",
+					tt.position, got.Points, got.FastestLapBonus, tt.points, tt.bonus)
+			}
+		})
+	}
+}
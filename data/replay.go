@@ -0,0 +1,140 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotRecorded is returned in replay mode for a request that was never recorded
+var ErrNotRecorded = errors.New("no recording for request")
+
+// recording is the on-disk format of one recorded response
+type recording struct {
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+	// Text marks bodies that were not JSON and are stored as a JSON string
+	Text bool `json:"text,omitempty"`
+}
+
+func recordingPath(dir, url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// RecordingTransport saves every response it sees to Dir
+type RecordingTransport struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.save(req.URL.String(), resp.StatusCode, body); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", req.URL, err)
+	}
+
+	return resp, nil
+}
+
+func (t *RecordingTransport) save(url string, status int, body []byte) error {
+	entry := recording{URL: url, Status: status, Body: body}
+	if !json.Valid(body) {
+		text, _ := json.Marshal(string(body))
+		entry.Body, entry.Text = text, true
+	}
+
+	raw, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(recordingPath(t.Dir, url), raw, 0o644)
+}
+
+// ReplayTransport serves responses previously saved by RecordingTransport
+// and never touches the network. Unrecorded URLs fail with ErrNotRecorded.
+type ReplayTransport struct {
+	Dir string
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+
+	raw, err := os.ReadFile(recordingPath(t.Dir, url))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w in %s", ErrNotRecorded, t.Dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var entry recording
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse recording for %s: %w", url, err)
+	}
+
+	body := []byte(entry.Body)
+	if entry.Text {
+		var text string
+		if err := json.Unmarshal(entry.Body, &text); err != nil {
+			return nil, fmt.Errorf("failed to parse recording for %s: %w", url, err)
+		}
+		body = []byte(text)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// EnableRecording makes client save every response to dir. The response
+// cache is turned off so that every request actually reaches the recorder.
+func EnableRecording(client *APIClient, dir string) {
+	client.Cache = nil
+	client.Client.Transport = &RecordingTransport{Dir: dir, Next: client.Client.Transport}
+}
+
+// EnableReplay makes client answer only from recordings in dir. Caching,
+// rate limiting and retries are pointless offline, so they are turned off.
+func EnableReplay(client *APIClient, dir string) {
+	client.Cache = nil
+	client.Limiter = nil
+	client.Retry = RetryPolicy{MaxAttempts: 1}
+	client.Client.Transport = &ReplayTransport{Dir: dir}
+}
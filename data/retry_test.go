@@ -0,0 +1,203 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient points an uncached, unthrottled client at srv with retries
+// fast enough for tests
+func newTestClient(srv *httptest.Server) *APIClient {
+	client := NewAPIClient()
+	client.BaseURL = srv.URL
+	client.Cache = nil
+	client.Limiter = nil
+	client.Retry = RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+	return client
+}
+
+// flakyServer answers with the given statuses in turn and 200 "ok" after them
+func flakyServer(t *testing.T, header http.Header, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&hits, 1))
+		if n <= len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestFetchRetriesTransientStatuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+	}{
+		{"server errors", []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable}},
+		{"too many requests", []int{http.StatusTooManyRequests}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := flakyServer(t, nil, tt.statuses...)
+			body, err := newTestClient(srv).makeRequest(context.Background(), "sessions")
+			if err != nil {
+				t.Fatalf("makeRequest: %v", err)
+			}
+			if string(body) != "ok" {
+				t.Errorf("body = %q, want %q", body, "ok")
+			}
+			if got, want := atomic.LoadInt32(hits), int32(len(tt.statuses)+1); got != want {
+				t.Errorf("server hit %d times, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFetchDoesNotRetryClientErrors(t *testing.T) {
+	srv, hits := flakyServer(t, nil, http.StatusNotFound)
+	_, err := newTestClient(srv).makeRequest(context.Background(), "sessions")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want a 404 APIError", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("server hit %d times, want 1", got)
+	}
+}
+
+func TestFetchHonoursRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"1"}}
+	srv, hits := flakyServer(t, header, http.StatusTooManyRequests)
+
+	start := time.Now()
+	if _, err := newTestClient(srv).makeRequest(context.Background(), "sessions"); err != nil {
+		t.Fatalf("makeRequest: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the 1s Retry-After", elapsed)
+	}
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Errorf("server hit %d times, want 2", got)
+	}
+}
+
+func TestFetchGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, hits := flakyServer(t, nil, 500, 500, 500, 500, 500, 500)
+	client := newTestClient(srv)
+	client.Retry.MaxAttempts = 3
+
+	_, err := client.makeRequest(context.Background(), "sessions")
+	if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempts") {
+		t.Fatalf("err = %v, want giving up after 3 attempts", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("err = %v, want it to wrap the last APIError", err)
+	}
+	if got := atomic.LoadInt32(hits); got != 3 {
+		t.Errorf("server hit %d times, want 3", got)
+	}
+}
+
+func TestFetchStopsWhenContextCancelled(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"60"}}
+	srv, hits := flakyServer(t, header, 503, 503, 503, 503)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := newTestClient(srv).makeRequest(ctx, "sessions")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("cancelled fetch took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Errorf("server hit %d times, want 1", got)
+	}
+}
+
+func TestFetchDoesNotRetryMissingRecordings(t *testing.T) {
+	if isRetryable(ErrNotRecorded) {
+		t.Error("ErrNotRecorded should not be retried")
+	}
+	if !isRetryable(errors.New("connection reset")) {
+		t.Error("network errors should be retried")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-3", 0},
+		{"3600", maxRetryAfter},
+		{"soon", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	// HTTP dates only have second precision
+	at := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(at); got < 28*time.Second || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 30s", at, got)
+	}
+}
+
+func TestIsNoData(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&APIError{StatusCode: http.StatusNotFound}, true},
+		{fmt.Errorf("laps: %w", &APIError{StatusCode: http.StatusNotFound}), true},
+		{&UnsupportedError{Source: "Jolpica", Data: "lap times"}, true},
+		{&APIError{StatusCode: http.StatusInternalServerError}, false},
+		{ErrNotRecorded, false},
+		{context.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNoData(tt.err); got != tt.want {
+			t.Errorf("IsNoData(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestBackoffStaysWithinCap(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry := 0; retry < 10; retry++ {
+		limit := min(policy.BaseDelay<<retry, policy.MaxDelay)
+		for i := 0; i < 50; i++ {
+			if d := policy.backoff(retry); d < 0 || d > limit {
+				t.Fatalf("backoff(%d) = %v, want 0..%v", retry, d, limit)
+			}
+		}
+	}
+}
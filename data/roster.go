@@ -0,0 +1,250 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Appearance is one session a driver took part in, with the team they entered it with
+type Appearance struct {
+	Session Session
+	Team    string
+	// Result is the driver's scored classification, nil for practice and
+	// sessions without a result yet
+	Result *ScoredResult
+}
+
+// RosterEntry is a driver who took part in the season, with every session they
+// appeared in, practice included. Driver.Team is the team of their latest appearance.
+type RosterEntry struct {
+	Driver
+	Appearances []Appearance
+}
+
+// FirstAppearance returns the driver's first session of the season
+func (e RosterEntry) FirstAppearance() (Appearance, bool) {
+	if len(e.Appearances) == 0 {
+		return Appearance{}, false
+	}
+	return e.Appearances[0], true
+}
+
+// LastAppearance returns the driver's most recent session of the season
+func (e RosterEntry) LastAppearance() (Appearance, bool) {
+	if len(e.Appearances) == 0 {
+		return Appearance{}, false
+	}
+	return e.Appearances[len(e.Appearances)-1], true
+}
+
+// Teams returns each team the driver raced for, in the order they did.
+// Practice outings for other teams don't count.
+func (e RosterEntry) Teams() []string {
+	var teams []string
+	for _, appearance := range e.Appearances {
+		if appearance.Team == "" || appearance.Result == nil {
+			continue
+		}
+		if len(teams) == 0 || teams[len(teams)-1] != appearance.Team {
+			teams = append(teams, appearance.Team)
+		}
+	}
+	return teams
+}
+
+// sessionEntrants pairs a session with the drivers entered in it
+type sessionEntrants struct {
+	Session Session
+	Drivers []Driver
+	Err     error
+}
+
+// practiceSessions lists the season's practice sessions that have started,
+// in calendar order
+func (ds *DataService) practiceSessions(ctx context.Context, source WeekendSource) ([]Session, error) {
+	sessions, err := source.WeekendSessions(ctx, ds.season, "Practice")
+	if err != nil {
+		return nil, err
+	}
+
+	var started []Session
+	for _, session := range sessions {
+		if session.State() != StateUpcoming {
+			started = append(started, session)
+		}
+	}
+	sort.SliceStable(started, func(i, j int) bool {
+		return started[i].DateStart.Before(started[j].DateStart)
+	})
+	return started, nil
+}
+
+// practiceEntrants returns the entry list of every practice session so far.
+// They are fetched once per season and kept, like the scored sessions.
+func (ds *DataService) practiceEntrants(ctx context.Context) ([]sessionEntrants, error) {
+	source, ok := ds.source.(EntrantSource)
+	if !ok {
+		return nil, nil
+	}
+	weekend, ok := ds.source.(WeekendSource)
+	if !ok {
+		return nil, nil
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.entrants != nil && ds.entrantsSeason == ds.season {
+		return ds.entrants, nil
+	}
+
+	sessions, err := ds.practiceSessions(ctx, weekend)
+	if err != nil {
+		return nil, err
+	}
+	drivers, errs := fetchEach(ctx, ds.workers(), sessions, source.Entrants)
+	entrants := make([]sessionEntrants, len(sessions))
+	for i, session := range sessions {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to fetch entrants for %s %s (session %d): %w",
+				session.Location, session.Name, session.Key, errs[i])
+		}
+		entrants[i] = sessionEntrants{Session: session, Drivers: drivers[i]}
+	}
+
+	ds.entrants = entrants
+	ds.entrantsSeason = ds.season
+	return entrants, nil
+}
+
+// unexplained reports whether a listed driver isn't classified in any scored
+// session, as a reserve who has only run in practice isn't
+func unexplained(drivers []Driver, scored []ScoredSession) bool {
+	classified := make(map[int]bool)
+	for _, session := range scored {
+		for _, result := range session.Results {
+			classified[result.DriverNumber] = true
+		}
+	}
+	for _, driver := range drivers {
+		if !classified[driver.Number] {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRoster merges the source's entry list with everyone entered in a
+// session and everyone classified in a scored one. Listed drivers keep their
+// order; reserves, substitutes and dropped drivers follow in order of first
+// appearance.
+func buildRoster(drivers []Driver, entrants []sessionEntrants, scored []ScoredSession) []RosterEntry {
+	var roster []RosterEntry
+	index := make(map[int]int)
+	add := func(driver Driver) int {
+		if i, seen := index[driver.Number]; seen {
+			return i
+		}
+		index[driver.Number] = len(roster)
+		roster = append(roster, RosterEntry{Driver: driver})
+		return len(roster) - 1
+	}
+
+	for _, driver := range drivers {
+		add(driver)
+	}
+
+	// Every session on one timeline; classified drivers missing from an
+	// entry list, or sessions the source has no entry lists for, still count
+	timeline := make([]sessionEntrants, len(entrants))
+	copy(timeline, entrants)
+	position := make(map[int]int)
+	for i, session := range timeline {
+		position[session.Session.Key] = i
+	}
+	results := make(map[int]map[int]*ScoredResult)
+	for s := range scored {
+		session := &scored[s]
+		i, known := position[session.Session.Key]
+		if !known {
+			i = len(timeline)
+			position[session.Session.Key] = i
+			timeline = append(timeline, sessionEntrants{Session: session.Session})
+		}
+
+		byNumber := make(map[int]*ScoredResult)
+		entered := make(map[int]bool)
+		for _, driver := range timeline[i].Drivers {
+			entered[driver.Number] = true
+		}
+		for r := range session.Results {
+			result := &session.Results[r]
+			byNumber[result.DriverNumber] = result
+			if !entered[result.DriverNumber] {
+				timeline[i].Drivers = append(timeline[i].Drivers, Driver{
+					ID:     result.DriverNumber,
+					Name:   result.Driver,
+					Number: result.DriverNumber,
+					Team:   result.Team,
+				})
+			}
+		}
+		results[session.Session.Key] = byNumber
+	}
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Session.DateStart.Before(timeline[j].Session.DateStart)
+	})
+
+	for _, session := range timeline {
+		for _, driver := range session.Drivers {
+			entry := &roster[add(driver)]
+			if entry.Name == "" {
+				entry.Name = driver.Name
+			}
+
+			appearance := Appearance{Session: session.Session, Team: driver.Team}
+			if result := results[session.Session.Key][driver.Number]; result != nil {
+				appearance.Result = result
+				if result.Team != "" {
+					appearance.Team = result.Team
+				}
+			}
+			entry.Appearances = append(entry.Appearances, appearance)
+			if appearance.Team != "" {
+				entry.Team = appearance.Team
+			}
+		}
+	}
+
+	return roster
+}
+
+// GetRoster returns every driver who took part in the season, including
+// substitutes and drivers who have since been replaced. Practice entry lists
+// are only fetched when a listed driver hasn't been classified anywhere, to
+// find reserves who have only run in practice.
+func (ds *DataService) GetRoster() ([]RosterEntry, error) {
+	return ds.GetRosterContext(context.Background())
+}
+
+// GetRosterContext is like GetRoster but takes a context for cancellation
+func (ds *DataService) GetRosterContext(ctx context.Context) ([]RosterEntry, error) {
+	drivers, err := ds.source.Drivers(ctx, ds.season)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := ds.scoreCompletedSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var entrants []sessionEntrants
+	if unexplained(drivers, sessions) {
+		if entrants, err = ds.practiceEntrants(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildRoster(drivers, entrants, sessions), nil
+}
@@ -0,0 +1,153 @@
+package data
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSource serves canned sessions, classifications and entry lists
+type fakeSource struct {
+	drivers         []Driver
+	sessions        []Session
+	weekend         map[string][]Session
+	classifications map[int][]SessionResult
+	entrants        map[int][]Driver
+	// entrantCalls counts entry list requests
+	entrantCalls atomic.Int32
+}
+
+func (f *fakeSource) Name() string                   { return "fake" }
+func (f *fakeSource) FirstSeason() int               { return 2023 }
+func (f *fakeSource) Ping(ctx context.Context) error { return nil }
+
+func (f *fakeSource) Drivers(ctx context.Context, season int) ([]Driver, error) {
+	return f.drivers, nil
+}
+
+func (f *fakeSource) Sessions(ctx context.Context, season int) ([]Session, error) {
+	return f.sessions, nil
+}
+
+func (f *fakeSource) Classification(ctx context.Context, session Session) ([]SessionResult, error) {
+	return f.classifications[session.Key], nil
+}
+
+func (f *fakeSource) Schedule(ctx context.Context, season int) ([]Race, error) {
+	return nil, nil
+}
+
+func (f *fakeSource) WeekendSessions(ctx context.Context, season int, sessionType string) ([]Session, error) {
+	return f.weekend[sessionType], nil
+}
+
+func (f *fakeSource) Entrants(ctx context.Context, session Session) ([]Driver, error) {
+	f.entrantCalls.Add(1)
+	return f.entrants[session.Key], nil
+}
+
+// fakeSession is a session of the 2024 season that finished long ago
+func fakeSession(key int, name, sessionType string, day int) Session {
+	start := time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC)
+	return Session{
+		Key: key, Name: name, Type: sessionType, Location: "Jeddah", Round: 2, Year: 2024,
+		DateStart: start, DateEnd: start.Add(time.Hour),
+	}
+}
+
+func TestRosterIncludesPracticeReserves(t *testing.T) {
+	sainz := Driver{Name: "Carlos Sainz", Number: 55, Team: "Ferrari"}
+	leclerc := Driver{Name: "Charles Leclerc", Number: 16, Team: "Ferrari"}
+	bearman := Driver{Name: "Oliver Bearman", Number: 38, Team: "Ferrari"}
+	reserve := Driver{Name: "Robert Shwartzman", Number: 39, Team: "Ferrari"}
+
+	practice := fakeSession(1, "Practice 1", "Practice", 7)
+	race := fakeSession(3, "Race", "Race", 9)
+	source := &fakeSource{
+		drivers:  []Driver{leclerc, sainz},
+		sessions: []Session{race},
+		weekend:  map[string][]Session{"Practice": {practice}},
+		entrants: map[int][]Driver{
+			practice.Key: {leclerc, sainz, reserve},
+			race.Key:     {leclerc, bearman},
+		},
+		classifications: map[int][]SessionResult{
+			race.Key: {
+				{DriverNumber: 16, Position: 1, Driver: leclerc.Name, Team: "Ferrari"},
+				{DriverNumber: 38, Position: 2, Driver: bearman.Name, Team: "Ferrari"},
+			},
+		},
+	}
+
+	roster, err := NewDataServiceWithSource(source, 2024).GetRoster()
+	if err != nil {
+		t.Fatalf("GetRoster: %v", err)
+	}
+
+	var names []string
+	for _, entry := range roster {
+		names = append(names, entry.Name)
+	}
+	want := []string{"Charles Leclerc", "Carlos Sainz", "Robert Shwartzman", "Oliver Bearman"}
+	if len(names) != len(want) {
+		t.Fatalf("roster = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("roster = %v, want %v", names, want)
+		}
+	}
+
+	// Leclerc practised and raced; only the race carries a score
+	appearances := roster[0].Appearances
+	if len(appearances) != 2 || appearances[0].Result != nil || appearances[1].Result == nil ||
+		appearances[1].Result.Points != 25 {
+		t.Errorf("Leclerc's appearances = %+v", appearances)
+	}
+	if teams := roster[2].Teams(); len(teams) != 0 {
+		t.Errorf("a practice-only reserve raced for %v", teams)
+	}
+	if first, ok := roster[2].FirstAppearance(); !ok || first.Session.Key != practice.Key {
+		t.Errorf("reserve's first appearance = %+v", first)
+	}
+}
+
+func TestRosterFetchesPracticeOnlyWhenNeeded(t *testing.T) {
+	leclerc := Driver{Name: "Charles Leclerc", Number: 16, Team: "Ferrari"}
+	sainz := Driver{Name: "Carlos Sainz", Number: 55, Team: "Ferrari"}
+	practice := fakeSession(1, "Practice 1", "Practice", 7)
+	race := fakeSession(3, "Race", "Race", 9)
+	newSource := func(drivers ...Driver) *fakeSource {
+		return &fakeSource{
+			drivers:  drivers,
+			sessions: []Session{race},
+			weekend:  map[string][]Session{"Practice": {practice}},
+			entrants: map[int][]Driver{practice.Key: {leclerc, sainz}},
+			classifications: map[int][]SessionResult{
+				race.Key: {{DriverNumber: 16, Position: 1, Driver: leclerc.Name, Team: "Ferrari"}},
+			},
+		}
+	}
+
+	// Everyone listed is classified, so no entry list is needed
+	source := newSource(leclerc)
+	if _, err := NewDataServiceWithSource(source, 2024).GetRoster(); err != nil {
+		t.Fatalf("GetRoster: %v", err)
+	}
+	if calls := source.entrantCalls.Load(); calls != 0 {
+		t.Errorf("fetched %d entry lists with every driver classified", calls)
+	}
+
+	// Sainz hasn't been classified, so practice is fetched, once
+	source = newSource(leclerc, sainz)
+	ds := NewDataServiceWithSource(source, 2024)
+	for i := 0; i < 2; i++ {
+		if _, err := ds.GetRoster(); err != nil {
+			t.Fatalf("GetRoster: %v", err)
+		}
+	}
+	if calls := source.entrantCalls.Load(); calls != 1 {
+		t.Errorf("fetched %d entry lists over two rosters, want 1", calls)
+	}
+}
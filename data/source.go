@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DataSource is a backend that supplies season data to the DataService.
+// Implementations only fetch and convert data; scoring and standings are
+// computed by the DataService so every backend is treated the same way.
+type DataSource interface {
+	// Name identifies the backend in command output
+	Name() string
+	// FirstSeason is the earliest championship the backend has data for
+	FirstSeason() int
+	// Ping reports whether the backend is reachable
+	Ping(ctx context.Context) error
+	// Drivers returns the drivers entered in a season
+	Drivers(ctx context.Context, season int) ([]Driver, error)
+	// Sessions returns a season's race and sprint sessions in calendar order
+	Sessions(ctx context.Context, season int) ([]Session, error)
+	// Classification returns the final order of a session
+	Classification(ctx context.Context, session Session) ([]SessionResult, error)
+	// Schedule returns a season's meetings
+	Schedule(ctx context.Context, season int) ([]Race, error)
+}
+
+// StandingsSource is implemented by sources that publish official championship
+// standings. The DataService uses them instead of computing its own.
+type StandingsSource interface {
+	DriverStandings(ctx context.Context, season int) ([]StandingEntry, error)
+	ConstructorStandings(ctx context.Context, season int) ([]StandingEntry, error)
+}
+
+// WeekendSource is implemented by sources that know a race weekend's other
+// sessions, not just its races and sprints
+type WeekendSource interface {
+	// WeekendSessions returns a season's sessions of one type ("Qualifying",
+	// "Practice") in calendar order
+	WeekendSessions(ctx context.Context, season int, sessionType string) ([]Session, error)
+}
+
+// QualifyingSource is implemented by sources with qualifying results
+type QualifyingSource interface {
+	// Qualifying returns a qualifying session's results in grid order
+	Qualifying(ctx context.Context, session Session) ([]QualifyingResult, error)
+}
+
+// LapSource is implemented by sources with lap-by-lap timing
+type LapSource interface {
+	// Laps returns a session's laps in the order they were started. A
+	// driverNumber of zero returns every driver's laps.
+	Laps(ctx context.Context, session Session, driverNumber int) ([]Lap, error)
+}
+
+// FastestLapSource is implemented by sources that publish each race's
+// fastest lap. For other sources it is worked out from their laps.
+type FastestLapSource interface {
+	FastestLap(ctx context.Context, session Session) (*FastestLap, error)
+}
+
+// StintSource is implemented by sources that know which tyres were used
+type StintSource interface {
+	// Stints returns a session's tyre stints, or one driver's when
+	// driverNumber isn't zero
+	Stints(ctx context.Context, session Session, driverNumber int) ([]Stint, error)
+}
+
+// WeatherSource is implemented by sources with weather readings from the track
+type WeatherSource interface {
+	// Weather returns a session's readings in time order
+	Weather(ctx context.Context, session Session) ([]WeatherSample, error)
+}
+
+// PitSource is implemented by sources that time pit stops
+type PitSource interface {
+	// PitStops returns a session's pit stops in time order
+	PitStops(ctx context.Context, session Session) ([]PitStop, error)
+}
+
+// RaceControlSource is implemented by sources with race control messages
+type RaceControlSource interface {
+	// RaceControl returns a session's messages in time order
+	RaceControl(ctx context.Context, session Session) ([]RaceControlMessage, error)
+}
+
+// EntrantSource is implemented by sources that list who took part in each
+// session, including reserve drivers who only run in practice
+type EntrantSource interface {
+	// Entrants returns the drivers entered in a session, with the team they
+	// entered it with
+	Entrants(ctx context.Context, session Session) ([]Driver, error)
+}
+
+// CachingSource is implemented by sources that keep an on-disk response cache
+type CachingSource interface {
+	Cache() *ResponseCache
+	SetCache(cache *ResponseCache)
+}
+
+// HTTPSource is implemented by sources backed by an APIClient
+type HTTPSource interface {
+	Client() *APIClient
+}
+
+// UnsupportedError is returned when the source doesn't offer a kind of data at all
+type UnsupportedError struct {
+	Source string
+	Data   string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("%s has no %s", e.Source, e.Data)
+}
+
+// DefaultSourceName is used when neither --source nor the config file picks one
+const DefaultSourceName = "openf1"
+
+// HistoricalSourceName is used for seasons the default source does not cover
+const HistoricalSourceName = "jolpica"
+
+// sourceFactories maps a source name, as used by --source, to its constructor
+var sourceFactories = map[string]func() DataSource{
+	"openf1":  func() DataSource { return NewOpenF1Source(NewAPIClient()) },
+	"jolpica": func() DataSource { return NewErgastSource(NewJolpicaClient()) },
+	"ergast":  func() DataSource { return NewErgastSource(NewJolpicaClient()) },
+}
+
+// RegisterSource makes a backend selectable by name
+func RegisterSource(name string, factory func() DataSource) {
+	sourceFactories[strings.ToLower(name)] = factory
+}
+
+// NewSource builds the backend registered under name
+func NewSource(name string) (DataSource, error) {
+	factory, ok := sourceFactories[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown data source %q (available: %s)",
+			name, strings.Join(SourceNames(), ", "))
+	}
+	return factory(), nil
+}
+
+// SourceNames lists the registered backends in alphabetical order
+func SourceNames() []string {
+	names := make([]string, 0, len(sourceFactories))
+	for name := range sourceFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,241 @@
+package data
+
+import (
+	"context"
+	"fmt"
+)
+
+// StandingData holds points, wins and the countback tally for standings calculation
+type StandingData struct {
+	Points    float64
+	Wins      int
+	Countback []int
+}
+
+// ScoredSession is a completed session with its classification scored
+type ScoredSession struct {
+	Session Session
+	// State is StateProvisional while stewards' decisions may still change the result
+	State   SessionState
+	Results []ScoredResult
+}
+
+// scoreCompletedSessions fetches and scores every race and sprint of the
+// season that has ended and has a classification, in calendar order. The result is kept for the rest of the run,
+// since standings, the roster and driver details all start from it.
+func (ds *DataService) scoreCompletedSessions(ctx context.Context) ([]ScoredSession, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	if ds.scored != nil && ds.scoredSeason == ds.season {
+		return ds.scored, nil
+	}
+
+	// Get all race and sprint sessions for the season
+	sessions, err := ds.source.Sessions(ctx, ds.season)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only sessions that have ended count; a live session's order is partial
+	var completed []Session
+	for _, session := range sessions {
+		if session.HasEnded() {
+			completed = append(completed, session)
+		}
+	}
+
+	// A missing session would silently produce wrong totals, so fail instead
+	fetches := ds.FetchClassificationsContext(ctx, completed)
+	if err := FirstFetchError(fetches); err != nil {
+		return nil, fmt.Errorf("failed to fetch session results: %w", err)
+	}
+
+	scored := make([]ScoredSession, 0, len(fetches))
+	for _, fetch := range fetches {
+		// A session that has ended but has no classification yet is skipped
+		// until the result is published
+		if len(fetch.Results) == 0 {
+			continue
+		}
+		scored = append(scored, ScoredSession{
+			Session: fetch.Session,
+			State:   fetch.Session.State(),
+			Results: ds.ScoreSession(fetch.Session, fetch.Results),
+		})
+	}
+
+	ds.scored, ds.scoredSeason = scored, ds.season
+	return scored, nil
+}
+
+// GetDriverStandings calculates real driver standings from the season's race results
+func (ds *DataService) GetDriverStandings() ([]StandingEntry, error) {
+	return ds.GetDriverStandingsContext(context.Background())
+}
+
+// GetDriverStandingsContext is like GetDriverStandings but takes a context for cancellation
+func (ds *DataService) GetDriverStandingsContext(ctx context.Context) ([]StandingEntry, error) {
+	// Prefer official standings when the source publishes them
+	if official, ok := ds.source.(StandingsSource); ok {
+		return official.DriverStandings(ctx, ds.season)
+	}
+
+	// Get all drivers first
+	drivers, err := ds.source.Drivers(ctx, ds.season)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := ds.scoreCompletedSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Everyone who raced keeps their points, even if they have since been
+	// replaced; practice-only reserves have none, so entry lists are skipped
+	roster := buildRoster(drivers, nil, sessions)
+
+	// Initialize driver standings map
+	driverPoints := make(map[int]*StandingData)
+	driverNames := make(map[int]string)
+	driverTeams := make(map[int]string)
+
+	for _, entry := range roster {
+		driver := entry.Driver
+		driverPoints[driver.Number] = &StandingData{
+			Points: 0,
+			Wins:   0,
+		}
+		driverNames[driver.Number] = driver.Name
+		driverTeams[driver.Number] = driver.Team
+	}
+
+	// Award points for the classification after stewards' decisions
+	for _, session := range sessions {
+		for _, result := range session.Results {
+			if standing, exists := driverPoints[result.DriverNumber]; exists {
+				standing.Points += result.Points
+				if result.Win {
+					standing.Wins++
+				}
+				if countsForCountback(session.Session, result) {
+					standing.Countback = addFinish(standing.Countback, result.ClassifiedPosition)
+				}
+			}
+		}
+	}
+
+	// Convert to sorted standings
+	var standings []StandingEntry
+	// Walk the roster rather than the map so ties come out in a stable order
+	for _, entry := range roster {
+		driver := entry.Driver
+		standing := driverPoints[driver.Number]
+		if driverName, exists := driverNames[driver.Number]; exists {
+			standings = append(standings, StandingEntry{
+				Driver:    driverName,
+				Team:      driverTeams[driver.Number],
+				Points:    standing.Points,
+				Wins:      standing.Wins,
+				Countback: standing.Countback,
+			})
+		}
+	}
+
+	// Sort by points (highest first), then on countback
+	orderStandings(standings)
+
+	return standings, nil
+}
+
+// GetConstructorStandings calculates the season's constructor standings session by session
+func (ds *DataService) GetConstructorStandings() ([]StandingEntry, error) {
+	return ds.GetConstructorStandingsContext(context.Background())
+}
+
+// GetConstructorStandingsContext is like GetConstructorStandings but takes a context for cancellation
+func (ds *DataService) GetConstructorStandingsContext(ctx context.Context) ([]StandingEntry, error) {
+	if official, ok := ds.source.(StandingsSource); ok {
+		return official.ConstructorStandings(ctx, ds.season)
+	}
+
+	drivers, err := ds.source.Drivers(ctx, ds.season)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := ds.scoreCompletedSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Points go to the team a driver entered each session with, so a mid-season
+	// move doesn't take earlier points along; the roster only fills gaps
+	rosterTeams := make(map[int]string)
+	teamPoints := make(map[string]float64)
+	teamWins := make(map[string]int)
+	teamCountback := make(map[string][]int)
+	var teamOrder []string
+
+	addTeam := func(team string) {
+		if _, seen := teamPoints[team]; !seen {
+			teamPoints[team] = 0
+			teamOrder = append(teamOrder, team)
+		}
+	}
+	for _, driver := range drivers {
+		rosterTeams[driver.Number] = driver.Team
+		if driver.Team != "" {
+			addTeam(driver.Team)
+		}
+	}
+
+	for _, session := range sessions {
+		for _, result := range session.Results {
+			team := result.Team
+			if team == "" {
+				team = rosterTeams[result.DriverNumber]
+			}
+			if team == "" {
+				continue
+			}
+
+			addTeam(team)
+			teamPoints[team] += result.Points
+			if result.Win {
+				teamWins[team]++
+			}
+			if countsForCountback(session.Session, result) {
+				teamCountback[team] = addFinish(teamCountback[team], result.ClassifiedPosition)
+			}
+		}
+	}
+
+	// Convert to sorted standings
+	var standings []StandingEntry
+	for _, team := range teamOrder {
+		standings = append(standings, StandingEntry{
+			Driver:    team, // Using Driver field for team name
+			Team:      team,
+			Points:    teamPoints[team],
+			Wins:      teamWins[team],
+			Countback: teamCountback[team],
+		})
+	}
+
+	// Sort by points (highest first), then on countback
+	orderStandings(standings)
+
+	return standings, nil
+}
+
+// fillStandingGaps sets the gap-to-leader column of ordered standings
+func fillStandingGaps(standings []StandingEntry) {
+	for i := range standings {
+		if i == 0 {
+			standings[i].Gap = "Leader"
+		} else {
+			standings[i].Gap = "-" + FormatPoints(standings[0].Points-standings[i].Points)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package data
+
+import "sort"
+
+// TeamPitStops sums up one team's pit work in a session
+type TeamPitStops struct {
+	Team  string
+	Stops int
+	// Fastest is the team's quickest stop; Median is typical of all of them
+	Fastest PitStop
+	Median  float64
+}
+
+// Duration returns the stop's stationary time, or its pit lane time when
+// stationary is false
+func (p PitStop) Duration(stationary bool) float64 {
+	if stationary {
+		return p.StopTime
+	}
+	return p.LaneTime
+}
+
+// HasStationaryTimes reports whether any stop has its stationary time
+// recorded; OpenF1 only publishes them from 2025
+func HasStationaryTimes(stops []PitStop) bool {
+	for _, stop := range stops {
+		if stop.StopTime > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PitStopLeaderboard ranks teams by their fastest stop, on stationary times
+// when the session has them and pit lane times otherwise. teams maps car
+// numbers to team names; stops without a time are left out.
+func PitStopLeaderboard(stops []PitStop, teams map[int]string) []TeamPitStops {
+	stationary := HasStationaryTimes(stops)
+	times := make(map[string][]float64)
+	byTeam := make(map[string]*TeamPitStops)
+	for _, stop := range stops {
+		t := stop.Duration(stationary)
+		team := teams[stop.DriverNumber]
+		if t <= 0 || team == "" {
+			continue
+		}
+		entry, ok := byTeam[team]
+		if !ok {
+			entry = &TeamPitStops{Team: team, Fastest: stop}
+			byTeam[team] = entry
+		}
+		entry.Stops++
+		if t < entry.Fastest.Duration(stationary) {
+			entry.Fastest = stop
+		}
+		times[team] = append(times[team], t)
+	}
+
+	leaderboard := make([]TeamPitStops, 0, len(byTeam))
+	for team, entry := range byTeam {
+		entry.Median = median(times[team])
+		leaderboard = append(leaderboard, *entry)
+	}
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i].Fastest.Duration(stationary), leaderboard[j].Fastest.Duration(stationary)
+		if a != b {
+			return a < b
+		}
+		return leaderboard[i].Team < leaderboard[j].Team
+	})
+	return leaderboard
+}
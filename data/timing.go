@@ -0,0 +1,292 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetLaps returns a session's laps, or one driver's when driverNumber isn't zero
+func (ds *DataService) GetLaps(session Session, driverNumber int) ([]Lap, error) {
+	return ds.GetLapsContext(context.Background(), session, driverNumber)
+}
+
+// GetLapsContext is like GetLaps but takes a context for cancellation
+func (ds *DataService) GetLapsContext(ctx context.Context, session Session, driverNumber int) ([]Lap, error) {
+	source, ok := ds.source.(LapSource)
+	if !ok {
+		return nil, &UnsupportedError{Source: ds.source.Name(), Data: "lap times"}
+	}
+	return source.Laps(ctx, session, driverNumber)
+}
+
+// GetStints returns a session's tyre stints, or one driver's when driverNumber isn't zero
+func (ds *DataService) GetStints(session Session, driverNumber int) ([]Stint, error) {
+	return ds.GetStintsContext(context.Background(), session, driverNumber)
+}
+
+// GetStintsContext is like GetStints but takes a context for cancellation
+func (ds *DataService) GetStintsContext(ctx context.Context, session Session, driverNumber int) ([]Stint, error) {
+	source, ok := ds.source.(StintSource)
+	if !ok {
+		return nil, &UnsupportedError{Source: ds.source.Name(), Data: "tyre data"}
+	}
+	return source.Stints(ctx, session, driverNumber)
+}
+
+// GetPitStops returns a session's pit stops in time order
+func (ds *DataService) GetPitStops(session Session) ([]PitStop, error) {
+	return ds.GetPitStopsContext(context.Background(), session)
+}
+
+// GetPitStopsContext is like GetPitStops but takes a context for cancellation
+func (ds *DataService) GetPitStopsContext(ctx context.Context, session Session) ([]PitStop, error) {
+	source, ok := ds.source.(PitSource)
+	if !ok {
+		return nil, fmt.Errorf("%s has no pit stop data", ds.source.Name())
+	}
+	return source.PitStops(ctx, session)
+}
+
+// GetRaceControl returns a session's race control messages in time order
+func (ds *DataService) GetRaceControl(session Session) ([]RaceControlMessage, error) {
+	return ds.GetRaceControlContext(context.Background(), session)
+}
+
+// GetRaceControlContext is like GetRaceControl but takes a context for cancellation
+func (ds *DataService) GetRaceControlContext(ctx context.Context, session Session) ([]RaceControlMessage, error) {
+	source, ok := ds.source.(RaceControlSource)
+	if !ok {
+		return nil, fmt.Errorf("%s has no race control messages", ds.source.Name())
+	}
+	return source.RaceControl(ctx, session)
+}
+
+// GetEntrants returns the drivers entered in a session. Sources that don't
+// list entrants per session fall back to the season's entry list.
+func (ds *DataService) GetEntrants(session Session) ([]Driver, error) {
+	return ds.GetEntrantsContext(context.Background(), session)
+}
+
+// GetEntrantsContext is like GetEntrants but takes a context for cancellation
+func (ds *DataService) GetEntrantsContext(ctx context.Context, session Session) ([]Driver, error) {
+	if source, ok := ds.source.(EntrantSource); ok {
+		return source.Entrants(ctx, session)
+	}
+	return ds.source.Drivers(ctx, ds.season)
+}
+
+// String describes the lap as shown in the schedule, e.g. "Lando Norris 1:30.965 (lap 50)"
+func (f FastestLap) String() string {
+	text := f.Driver
+	if text == "" {
+		text = fmt.Sprintf("#%d", f.DriverNumber)
+	}
+	if f.Time > 0 {
+		text += " " + FormatLapTime(f.Time)
+	}
+	if f.Lap > 0 {
+		text += fmt.Sprintf(" (lap %d)", f.Lap)
+	}
+	return text
+}
+
+// FindFastestLap returns the quickest timed lap, the earliest of equal ones
+func FindFastestLap(laps []Lap) (Lap, bool) {
+	var fastest Lap
+	found := false
+	for _, lap := range laps {
+		if lap.Duration <= 0 {
+			continue
+		}
+		if !found || lap.Duration < fastest.Duration ||
+			lap.Duration == fastest.Duration && lap.Start.Before(fastest.Start) {
+			fastest, found = lap, true
+		}
+	}
+	return fastest, found
+}
+
+// GetFastestLap returns a session's fastest lap, or nil when none was timed
+func (ds *DataService) GetFastestLap(session Session) (*FastestLap, error) {
+	return ds.GetFastestLapContext(context.Background(), session)
+}
+
+// GetFastestLapContext is like GetFastestLap but takes a context for cancellation.
+// A finished session's fastest lap is only looked up once, and is kept in the
+// response cache so later runs don't download the session's laps again.
+func (ds *DataService) GetFastestLapContext(ctx context.Context, session Session) (*FastestLap, error) {
+	ds.fastestMu.Lock()
+	fastest, found := ds.fastestLaps[session.Key]
+	ds.fastestMu.Unlock()
+	if found {
+		return fastest, nil
+	}
+
+	fastest, found = ds.cachedFastestLap(session)
+	if !found {
+		var err error
+		if fastest, err = ds.fastestLap(ctx, session); err != nil {
+			return nil, err
+		}
+		ds.cacheFastestLap(session, fastest)
+	}
+	if session.HasEnded() {
+		ds.fastestMu.Lock()
+		if ds.fastestLaps == nil {
+			ds.fastestLaps = make(map[int]*FastestLap)
+		}
+		ds.fastestLaps[session.Key] = fastest
+		ds.fastestMu.Unlock()
+	}
+	return fastest, nil
+}
+
+// fastestLapCacheKey names a session's fastest lap in the response cache
+func (ds *DataService) fastestLapCacheKey(session Session) string {
+	return fmt.Sprintf("f1cli:fastest-lap/%s/%d", ds.source.Name(), session.Key)
+}
+
+// cachedFastestLap returns the fastest lap kept for a finished session by an
+// earlier run. A session where no lap was timed is kept as null.
+func (ds *DataService) cachedFastestLap(session Session) (*FastestLap, bool) {
+	cache := ds.GetCache()
+	if cache == nil || session.State() != StateFinished {
+		return nil, false
+	}
+	body, ok := cache.Get(ds.fastestLapCacheKey(session), CacheForever)
+	if !ok {
+		return nil, false
+	}
+	var fastest *FastestLap
+	if err := json.Unmarshal(body, &fastest); err != nil {
+		return nil, false
+	}
+	return fastest, true
+}
+
+// cacheFastestLap keeps a finished session's fastest lap for later runs
+func (ds *DataService) cacheFastestLap(session Session, fastest *FastestLap) {
+	cache := ds.GetCache()
+	if cache == nil || session.State() != StateFinished {
+		return
+	}
+	body, err := json.Marshal(fastest)
+	if err != nil {
+		return
+	}
+	// A failed cache write only costs us a refetch next time
+	_ = cache.Put(ds.fastestLapCacheKey(session), body)
+}
+
+// fastestLap asks the source for a session's fastest lap, or works it out from the laps
+func (ds *DataService) fastestLap(ctx context.Context, session Session) (*FastestLap, error) {
+	if source, ok := ds.source.(FastestLapSource); ok {
+		return source.FastestLap(ctx, session)
+	}
+
+	laps, err := ds.GetLapsContext(ctx, session, 0)
+	if err != nil {
+		return nil, err
+	}
+	lap, ok := FindFastestLap(laps)
+	if !ok {
+		return nil, nil
+	}
+
+	fastest := &FastestLap{DriverNumber: lap.DriverNumber, Lap: lap.Number, Time: lap.Duration}
+	entrants, err := ds.GetEntrantsContext(ctx, session)
+	if err != nil && !isNoData(err) {
+		return nil, err
+	}
+	for _, driver := range entrants {
+		if driver.Number == lap.DriverNumber {
+			fastest.Driver = driver.Name
+		}
+	}
+	return fastest, nil
+}
+
+// withFastestLap marks the driver who set the fastest lap when the source
+// doesn't, in seasons where it scores a bonus point. The lap found by an
+// earlier run is reused before the session's laps are downloaded. When the
+// source has no lap data for the session the results are returned unchanged;
+// any other failure is returned.
+func (ds *DataService) withFastestLap(ctx context.Context, session Session, results []SessionResult) ([]SessionResult, error) {
+	if session.Name != "Race" || RegulationsFor(session.Season()).FastestLapPoints == 0 {
+		return results, nil
+	}
+	for _, result := range results {
+		if result.FastestLap {
+			return results, nil
+		}
+	}
+	if !ds.hasFastestLaps() {
+		return results, nil
+	}
+
+	fastest, err := ds.GetFastestLapContext(ctx, session)
+	if err != nil {
+		if isNoData(err) {
+			return results, nil
+		}
+		return nil, err
+	}
+	if fastest == nil {
+		return results, nil
+	}
+	for i := range results {
+		results[i].FastestLap = results[i].DriverNumber == fastest.DriverNumber
+	}
+	return results, nil
+}
+
+// hasFastestLaps reports whether the source can tell who set a fastest lap
+func (ds *DataService) hasFastestLaps() bool {
+	if _, ok := ds.source.(FastestLapSource); ok {
+		return true
+	}
+	_, ok := ds.source.(LapSource)
+	return ok
+}
+
+// fillFastestLaps sets the fastest lap of every finished race whose schedule
+// entry lacks one. Like fillPolePositions, races the source has no laps for
+// are left empty and other failures are returned.
+func (ds *DataService) fillFastestLaps(ctx context.Context, races []Race) error {
+	if !ds.hasFastestLaps() {
+		return nil
+	}
+	missing := make(map[int]*Race)
+	for i := range races {
+		if races[i].Round > 0 && races[i].FastestLap == "" &&
+			(races[i].Status == "completed" || races[i].Status == "provisional") {
+			missing[races[i].Round] = &races[i]
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sessions, err := ds.GetSessionsContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		race, ok := missing[session.Round]
+		if !ok || session.Name != "Race" || !session.HasEnded() {
+			continue
+		}
+		fastest, err := ds.GetFastestLapContext(ctx, session)
+		if err != nil {
+			if isNoData(err) {
+				continue
+			}
+			return fmt.Errorf("failed to fetch the fastest lap of %s: %w", race.Name, err)
+		}
+		if fastest != nil {
+			race.FastestLap = fastest.String()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// lapFakeSource adds lap timing that always fails with err
+type lapFakeSource struct {
+	*fakeSource
+	err error
+}
+
+func (f *lapFakeSource) Laps(ctx context.Context, session Session, driverNumber int) ([]Lap, error) {
+	return nil, f.err
+}
+
+func TestClassificationFastestLapErrors(t *testing.T) {
+	race := fakeSession(3, "Race", "Race", 9)
+	results := []SessionResult{{DriverNumber: 16, Position: 1, Driver: "Charles Leclerc"}}
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+	}{
+		{"no laps published", &APIError{StatusCode: http.StatusNotFound}, nil},
+		{"missing recording", fmt.Errorf("%w in testdata", ErrNotRecorded), ErrNotRecorded},
+		{"server failure", &APIError{StatusCode: http.StatusBadGateway}, &APIError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &lapFakeSource{
+				fakeSource: &fakeSource{
+					sessions:        []Session{race},
+					classifications: map[int][]SessionResult{race.Key: results},
+				},
+				err: tt.err,
+			}
+			got, err := NewDataServiceWithSource(source, 2024).GetClassification(race)
+
+			switch target := tt.wantErr.(type) {
+			case nil:
+				if err != nil || len(got) != 1 || got[0].FastestLap {
+					t.Errorf("GetClassification = %+v, %v; want the results unchanged", got, err)
+				}
+			case *APIError:
+				if !errors.As(err, &target) {
+					t.Errorf("err = %v, want an APIError", err)
+				}
+			default:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+// countingLapSource serves fixed laps and counts how often they are asked for
+type countingLapSource struct {
+	*fakeSource
+	laps  []Lap
+	calls int
+}
+
+func (f *countingLapSource) Laps(ctx context.Context, session Session, driverNumber int) ([]Lap, error) {
+	f.calls++
+	return f.laps, nil
+}
+
+func TestFastestLapFoundOnce(t *testing.T) {
+	race := fakeSession(3, "Race", "Race", 9)
+	source := &countingLapSource{
+		fakeSource: &fakeSource{
+			sessions: []Session{race},
+			classifications: map[int][]SessionResult{race.Key: {
+				{DriverNumber: 16, Position: 1},
+				{DriverNumber: 55, Position: 2},
+			}},
+		},
+		laps: []Lap{
+			{DriverNumber: 16, Number: 10, Duration: 91.2},
+			{DriverNumber: 55, Number: 12, Duration: 90.8},
+		},
+	}
+	ds := NewDataServiceWithSource(source, 2024)
+
+	results, err := ds.GetClassification(race)
+	if err != nil {
+		t.Fatalf("GetClassification: %v", err)
+	}
+	if results[0].FastestLap || !results[1].FastestLap {
+		t.Errorf("fastest lap marked on %+v, want Sainz (#55)", results)
+	}
+
+	fastest, err := ds.GetFastestLap(race)
+	if err != nil || fastest == nil || fastest.DriverNumber != 55 || fastest.Lap != 12 {
+		t.Fatalf("GetFastestLap = %+v, %v; want #55 on lap 12", fastest, err)
+	}
+	if source.calls != 1 {
+		t.Errorf("laps downloaded %d times, want once", source.calls)
+	}
+}
+
+// cachingLapSource keeps its responses in an on-disk cache
+type cachingLapSource struct {
+	*countingLapSource
+	cache *ResponseCache
+}
+
+func (f *cachingLapSource) Cache() *ResponseCache         { return f.cache }
+func (f *cachingLapSource) SetCache(cache *ResponseCache) { f.cache = cache }
+
+func TestFastestLapKeptBetweenRuns(t *testing.T) {
+	race := fakeSession(3, "Race", "Race", 9)
+	empty := fakeSession(4, "Race", "Race", 16)
+	source := &cachingLapSource{
+		countingLapSource: &countingLapSource{
+			fakeSource: &fakeSource{sessions: []Session{race, empty}},
+			laps:       []Lap{{DriverNumber: 55, Number: 12, Duration: 90.8}},
+		},
+		cache: NewResponseCache(t.TempDir()),
+	}
+
+	first, err := NewDataServiceWithSource(source, 2024).GetFastestLap(race)
+	if err != nil || first == nil {
+		t.Fatalf("GetFastestLap = %+v, %v", first, err)
+	}
+
+	// A new run reads it back instead of downloading the laps
+	second, err := NewDataServiceWithSource(source, 2024).GetFastestLap(race)
+	if err != nil || second == nil || *second != *first {
+		t.Fatalf("GetFastestLap on the next run = %+v, %v; want %+v", second, err, first)
+	}
+	if source.calls != 1 {
+		t.Errorf("laps downloaded %d times over two runs, want once", source.calls)
+	}
+
+	// A session without a timed lap is remembered too
+	source.laps = nil
+	for run := 0; run < 2; run++ {
+		if fastest, err := NewDataServiceWithSource(source, 2024).GetFastestLap(empty); err != nil || fastest != nil {
+			t.Fatalf("GetFastestLap without laps = %+v, %v", fastest, err)
+		}
+	}
+	if source.calls != 2 {
+		t.Errorf("laps downloaded %d times, want twice", source.calls)
+	}
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// WeatherSummary sums up the conditions over a session
+type WeatherSummary struct {
+	Samples     int
+	RainSamples int
+	// FirstRain and LastRain bound the readings with rain, zero when it stayed dry
+	FirstRain time.Time
+	LastRain  time.Time
+
+	AirMin, AirMax           float64
+	TrackMin, TrackMax       float64
+	HumidityMin, HumidityMax float64
+	WindMax                  float64
+}
+
+// Wet reports whether rain fell at any point
+func (w WeatherSummary) Wet() bool {
+	return w.RainSamples > 0
+}
+
+// RainShare returns the fraction of readings taken while it was raining
+func (w WeatherSummary) RainShare() float64 {
+	if w.Samples == 0 {
+		return 0
+	}
+	return float64(w.RainSamples) / float64(w.Samples)
+}
+
+// SummariseWeather finds the range of each reading and when it rained
+func SummariseWeather(samples []WeatherSample) WeatherSummary {
+	summary := WeatherSummary{Samples: len(samples)}
+	for i, sample := range samples {
+		if i == 0 {
+			summary.AirMin, summary.AirMax = sample.AirTemp, sample.AirTemp
+			summary.TrackMin, summary.TrackMax = sample.TrackTemp, sample.TrackTemp
+			summary.HumidityMin, summary.HumidityMax = sample.Humidity, sample.Humidity
+		}
+		summary.AirMin = math.Min(summary.AirMin, sample.AirTemp)
+		summary.AirMax = math.Max(summary.AirMax, sample.AirTemp)
+		summary.TrackMin = math.Min(summary.TrackMin, sample.TrackTemp)
+		summary.TrackMax = math.Max(summary.TrackMax, sample.TrackTemp)
+		summary.HumidityMin = math.Min(summary.HumidityMin, sample.Humidity)
+		summary.HumidityMax = math.Max(summary.HumidityMax, sample.Humidity)
+		summary.WindMax = math.Max(summary.WindMax, sample.WindSpeed)
+
+		if sample.Rain {
+			summary.RainSamples++
+			if summary.FirstRain.IsZero() {
+				summary.FirstRain = sample.Time
+			}
+			summary.LastRain = sample.Time
+		}
+	}
+	return summary
+}
+
+// DownsampleWeather averages readings into at most n equal slices of the
+// session, for charts narrower than the number of readings. A slice counts
+// as rainy if any of its readings were.
+func DownsampleWeather(samples []WeatherSample, n int) []WeatherSample {
+	if n <= 0 || len(samples) <= n {
+		return samples
+	}
+
+	buckets := make([]WeatherSample, n)
+	for b := range buckets {
+		from, to := b*len(samples)/n, (b+1)*len(samples)/n
+		bucket := WeatherSample{Time: samples[from].Time}
+		var windX, windY float64
+		for _, sample := range samples[from:to] {
+			bucket.AirTemp += sample.AirTemp
+			bucket.TrackTemp += sample.TrackTemp
+			bucket.Humidity += sample.Humidity
+			bucket.Pressure += sample.Pressure
+			bucket.WindSpeed += sample.WindSpeed
+			bucket.Rain = bucket.Rain || sample.Rain
+			// Directions wrap around, so average them as vectors
+			angle := float64(sample.WindDirection) * math.Pi / 180
+			windX += math.Sin(angle)
+			windY += math.Cos(angle)
+		}
+		count := float64(to - from)
+		bucket.AirTemp /= count
+		bucket.TrackTemp /= count
+		bucket.Humidity /= count
+		bucket.Pressure /= count
+		bucket.WindSpeed /= count
+		bucket.WindDirection = (int(math.Round(math.Atan2(windX, windY)*180/math.Pi)) + 360) % 360
+		buckets[b] = bucket
+	}
+	return buckets
+}
+
+// GetWeather returns a session's weather readings in time order
+func (ds *DataService) GetWeather(session Session) ([]WeatherSample, error) {
+	return ds.GetWeatherContext(context.Background(), session)
+}
+
+// GetWeatherContext is like GetWeather but takes a context for cancellation
+func (ds *DataService) GetWeatherContext(ctx context.Context, session Session) ([]WeatherSample, error) {
+	source, ok := ds.source.(WeatherSource)
+	if !ok {
+		return nil, &UnsupportedError{Source: ds.source.Name(), Data: "weather data"}
+	}
+	return source.Weather(ctx, session)
+}
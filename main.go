@@ -1,22 +1,208 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"f1cli/commands"
 	"f1cli/data"
 )
 
+// globalOptions holds flags that apply to every command
+type globalOptions struct {
+	season    int
+	noCache   bool
+	source    string
+	record    string
+	replay    string
+	penalties string
+}
+
+// extractGlobalOptions pulls global flags out of the argument list so they can
+// appear before or after the command name. The remaining arguments are returned
+// in their original order.
+func extractGlobalOptions(args []string) (globalOptions, []string, error) {
+	opts := globalOptions{season: data.CurrentSeason()}
+	var remaining []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") {
+			remaining = append(remaining, arg)
+			continue
+		}
+
+		switch name {
+		case "season":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, nil, fmt.Errorf("flag --season needs a year")
+				}
+				i++
+				value = args[i]
+			}
+			year, err := strconv.Atoi(value)
+			if err != nil {
+				return opts, nil, fmt.Errorf("invalid season %q", value)
+			}
+			opts.season = year
+		case "no-cache":
+			opts.noCache = true
+		case "source":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, nil, fmt.Errorf("flag --source needs a name")
+				}
+				i++
+				value = args[i]
+			}
+			opts.source = value
+		case "penalties":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, nil, fmt.Errorf("flag --penalties needs a file")
+				}
+				i++
+				value = args[i]
+			}
+			opts.penalties = value
+		case "record", "replay":
+			if !hasValue {
+				if i+1 >= len(args) {
+					return opts, nil, fmt.Errorf("flag --%s needs a directory", name)
+				}
+				i++
+				value = args[i]
+			}
+			if name == "record" {
+				opts.record = value
+			} else {
+				opts.replay = value
+			}
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+
+	if opts.record != "" && opts.replay != "" {
+		return opts, nil, fmt.Errorf("--record and --replay cannot be used together")
+	}
+
+	return opts, remaining, nil
+}
+
+// newDataService builds the service for the source picked by --source, falling
+// back to the config file and then the default source
+func newDataService(opts globalOptions) (*data.DataService, error) {
+	config := data.Config{Source: data.DefaultSourceName}
+	if path, err := data.DefaultConfigPath(); err == nil {
+		if config, err = data.LoadConfig(path); err != nil {
+			return nil, err
+		}
+	}
+
+	sourceName := opts.source
+	if sourceName == "" {
+		sourceName = config.Source
+	}
+
+	source, err := data.NewSource(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an explicit --source, reach for the historical backend when the
+	// configured one doesn't cover the requested season
+	if opts.source == "" && opts.season < source.FirstSeason() {
+		if source, err = data.NewSource(data.HistoricalSourceName); err != nil {
+			return nil, err
+		}
+	}
+
+	dataService := data.NewDataServiceWithSource(source, opts.season)
+
+	// The penalties override comes from --penalties, then the config file,
+	// then penalties.json next to config.json
+	penaltiesPath := opts.penalties
+	if penaltiesPath != "" {
+		if _, err := os.Stat(penaltiesPath); err != nil {
+			return nil, fmt.Errorf("penalties ledger: %w", err)
+		}
+	} else if config.Penalties != "" {
+		penaltiesPath = config.Penalties
+	} else if path, err := data.DefaultPenaltiesPath(); err == nil {
+		penaltiesPath = path
+	}
+	if penaltiesPath != "" {
+		// A broken override still leaves the built-in ledger in place, so that
+		// 'f1 penalties validate' can report what is wrong with it
+		ledger, err := data.LoadLedger(penaltiesPath)
+		if err != nil {
+			fmt.Printf("⚠️  Ignoring penalties override: %v\n", err)
+		}
+		dataService.SetPenalties(ledger)
+	}
+
+	return dataService, nil
+}
+
 func main() {
-	dataService := data.NewDataService()
+	opts, args, err := extractGlobalOptions(os.Args[1:])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	dataService, err := newDataService(opts)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if err := dataService.SetSeason(opts.season); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if opts.noCache {
+		dataService.DisableCache()
+	}
+	if opts.record != "" {
+		if err := dataService.Record(opts.record); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if opts.replay != "" {
+		if err := dataService.Replay(opts.replay); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	if len(os.Args) < 2 {
+	if len(args) < 1 {
 		showWelcomeAndHelp()
 		return
 	}
 
-	userCommand := os.Args[1]
+	userCommand := args[0]
+
+	// Ctrl-C cancels the context, which aborts any in-flight API requests
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer func() {
+		// Check before stop(), which cancels the context itself
+		interrupted := errors.Is(ctx.Err(), context.Canceled)
+		stop()
+		if interrupted {
+			fmt.Println("\nInterrupted")
+			os.Exit(130)
+		}
+	}()
 
 	if userCommand == "--help" || userCommand == "-h" {
 		showWelcomeAndHelp()
@@ -25,18 +211,34 @@ func main() {
 
 	switch userCommand {
 	case "drivers":
-		commands.DriversWithService(os.Args[2:], dataService)
+		commands.DriversWithService(ctx, args[1:], dataService)
 	case "standings":
-		commands.Standings(os.Args[2:], dataService)
+		commands.Standings(ctx, args[1:], dataService)
 	case "results":
-		commands.Results(os.Args[2:], dataService)
+		commands.Results(ctx, args[1:], dataService)
+	case "qualifying":
+		commands.Qualifying(ctx, args[1:], dataService)
+	case "practice":
+		commands.Practice(ctx, args[1:], dataService)
+	case "laps":
+		commands.Laps(ctx, args[1:], dataService)
+	case "strategy":
+		commands.Strategy(ctx, args[1:], dataService)
+	case "degradation":
+		commands.Degradation(ctx, args[1:], dataService)
+	case "weather":
+		commands.Weather(ctx, args[1:], dataService)
 	case "points":
-		commands.Points(os.Args[2:], dataService)
+		commands.Points(ctx, args[1:], dataService)
 	case "status":
-		commands.Status(os.Args[2:], dataService)
+		commands.Status(ctx, args[1:], dataService)
+	case "cache":
+		commands.Cache(ctx, args[1:], dataService)
+	case "penalties":
+		commands.Penalties(ctx, args[1:], dataService)
 	case "help":
-		if len(os.Args) > 2 {
-			showSpecificCommandHelp(os.Args[2])
+		if len(args) > 1 {
+			showSpecificCommandHelp(args[1])
 		} else {
 			showWelcomeAndHelp()
 		}
@@ -54,14 +256,30 @@ func showWelcomeAndHelp() {
 	fmt.Println("You can check driver standings, race results, and much more!")
 	fmt.Println()
 	fmt.Println("How to use this tool:")
-	fmt.Println("  f1 <command> [options] [arguments]")
+	fmt.Println("  f1 [global options] <command> [options] [arguments]")
+	fmt.Println()
+	fmt.Println("Global Options:")
+	fmt.Println("  --season <year>  Championship year to report on (default: current year)")
+	fmt.Println("  --no-cache       Skip the on-disk response cache and always use the network")
+	fmt.Println("  --source <name>  Data source to use (default: openf1, or \"source\" in config.json)")
+	fmt.Println("  --record <dir>   Save every API response to <dir> for later offline use")
+	fmt.Println("  --replay <dir>   Answer every request from <dir> without touching the network")
+	fmt.Println("  --penalties <file>  Merge a stewards' decisions ledger into the built-in one")
 	fmt.Println()
 	fmt.Println("Available Commands:")
 	fmt.Println("  drivers      Discover information about F1 drivers")
 	fmt.Println("  standings    View championship standings (drivers or teams)")
 	fmt.Println("  results      See race and sprint results from specific locations")
+	fmt.Println("  qualifying   See qualifying part by part, with the knockouts")
+	fmt.Println("  practice     See practice timesheets and long-run pace")
+	fmt.Println("  laps         List every lap a driver ran, with sector times")
+	fmt.Println("  strategy     See tyre strategies and pit stops for a race")
+	fmt.Println("  degradation  Measure how fast each tyre compound lost pace")
+	fmt.Println("  weather      Chart temperatures, wind and rain through a session")
 	fmt.Println("  points       Deep dive into a driver's points breakdown")
 	fmt.Println("  status       Check if our data source is working properly")
+	fmt.Println("  cache        Inspect or clear the local response cache")
+	fmt.Println("  penalties    List or validate the stewards' decisions ledger")
 	fmt.Println("  help         Get help (you're looking at it now!)")
 	fmt.Println()
 	fmt.Println("Quick Examples to Get Started:")
@@ -69,10 +287,19 @@ func showWelcomeAndHelp() {
 	fmt.Println("  f1 standings                   → View the driver championship")
 	fmt.Println("  f1 standings -c                → View the constructor championship")
 	fmt.Println("  f1 results Shanghai            → See Shanghai Grand Prix results")
+	fmt.Println("  f1 qualifying Monaco           → See how the Monaco grid was set")
+	fmt.Println("  f1 practice Silverstone 2      → Preview race pace from FP2 long runs")
+	fmt.Println("  f1 laps Monza LEC              → Every lap of Leclerc's Monza race")
+	fmt.Println("  f1 strategy last               → Tyre strategies and the fastest pit crews")
+	fmt.Println("  f1 degradation Bahrain         → Tyre wear per compound and per stint")
+	fmt.Println("  f1 weather Interlagos          → When the rain came and how hot the track got")
 	fmt.Println("  f1 points \"Oscar Piastri\"      → See how Oscar earned his points")
+	fmt.Println("  f1 points PIA                  → Same, by acronym (or car number, or surname)")
 	fmt.Println("  f1 drivers -d                  → Get detailed driver information")
 	fmt.Println("  f1 drivers \"Lewis Hamilton\"    → Focus on a specific driver")
 	fmt.Println("  f1 status                      → Make sure everything is working")
+	fmt.Println("  f1 --season 2023 standings     → View the 2023 driver championship")
+	fmt.Println("  f1 standings --season 1998     → Historical seasons use the Jolpica (Ergast) API")
 	fmt.Println("  f1 help drivers                → Learn more about the drivers command")
 	fmt.Println()
 	fmt.Println("Pro Tip: Use 'f1 help <command>' to learn more about any specific command!")
@@ -93,13 +320,45 @@ func showSpecificCommandHelp(commandName string) {
 		fmt.Println("Getting help for the 'results' command...")
 		fmt.Println()
 		commands.ShowResultsHelp()
+	case "qualifying":
+		fmt.Println("Getting help for the 'qualifying' command...")
+		fmt.Println()
+		commands.ShowQualifyingHelp()
+	case "practice":
+		fmt.Println("Getting help for the 'practice' command...")
+		fmt.Println()
+		commands.ShowPracticeHelp()
+	case "laps":
+		fmt.Println("Getting help for the 'laps' command...")
+		fmt.Println()
+		commands.ShowLapsHelp()
+	case "strategy":
+		fmt.Println("Getting help for the 'strategy' command...")
+		fmt.Println()
+		commands.ShowStrategyHelp()
+	case "degradation":
+		fmt.Println("Getting help for the 'degradation' command...")
+		fmt.Println()
+		commands.ShowDegradationHelp()
+	case "weather":
+		fmt.Println("Getting help for the 'weather' command...")
+		fmt.Println()
+		commands.ShowWeatherHelp()
 	case "points":
 		fmt.Println("Getting help for the 'points' command...")
 		fmt.Println()
 		commands.ShowPointsHelp()
+	case "cache":
+		fmt.Println("Getting help for the 'cache' command...")
+		fmt.Println()
+		commands.ShowCacheHelp()
+	case "penalties":
+		fmt.Println("Getting help for the 'penalties' command...")
+		fmt.Println()
+		commands.ShowPenaltiesHelp()
 	default:
 		fmt.Printf("❌ Sorry, I don't have specific help for the command: %s\n", commandName)
-		fmt.Println("Try one of these commands for help: drivers, standings, results, points")
+		fmt.Println("Try one of these commands for help: drivers, standings, results, qualifying, practice, laps, strategy, degradation, weather, points, cache, penalties")
 		fmt.Println("Or use 'f1 help' to see all available commands")
 	}
 }